@@ -26,6 +26,8 @@ class BuildAnalytics {
 			enableOptimizationSuggestions: options.enableOptimizationSuggestions ?? true,
 			historyLimit: options.historyLimit ?? 50,
 			dataDir: options.dataDir ?? path.join(__dirname, "../../analytics-data"),
+			silent: options.silent ?? false,
+			profile: options.profile ?? "default",
 			...options
 		};
 
@@ -40,7 +42,8 @@ class BuildAnalytics {
 			memoryUsage: {},
 			errors: [],
 			warnings: [],
-			optimizations: []
+			optimizations: [],
+			steps: []
 		};
 
 		this.history = [];
@@ -61,8 +64,11 @@ class BuildAnalytics {
 		this.metrics.buildId = this.generateBuildId();
 		this.metrics.timestamp = new Date().toISOString();
 		this.metrics.version = this.getProjectVersion();
+		this.metrics.profile = this.options.profile;
 
-		console.log(`🔍 Build Analytics Started - ID: ${this.metrics.buildId}`);
+		if (!this.options.silent) {
+			console.log(`🔍 Build Analytics Started - ID: ${this.metrics.buildId}`);
+		}
 		return this.metrics.buildId;
 	}
 
@@ -87,6 +93,27 @@ class BuildAnalytics {
 		}
 	}
 
+	// Track the start of a build step, closing any step still running
+	startStep(name) {
+		this.endStep();
+		this.metrics.steps.push({
+			name,
+			start: Date.now(),
+			end: 0,
+			duration: 0,
+			status: "running"
+		});
+	}
+
+	// Track the end of the running step
+	endStep(status = "completed") {
+		const step = this.metrics.steps.find(s => s.status === "running");
+		if (!step) return;
+		step.end = Date.now();
+		step.duration = step.end - step.start;
+		step.status = status;
+	}
+
 	// Track bundle sizes
 	trackBundle(bundleName, size, type = "js") {
 		this.metrics.bundleSizes[bundleName] = {
@@ -105,6 +132,26 @@ class BuildAnalytics {
 		}
 	}
 
+	// Track the built assets directory: JS/CSS files become bundles, everything counts towards the asset totals
+	trackAssetDirectory(dir) {
+		if (!fs.existsSync(dir)) return;
+
+		let count = 0;
+		let totalSize = 0;
+		for (const entry of fs.readdirSync(dir, { withFileTypes: true })) {
+			if (!entry.isFile()) continue;
+			const size = fs.statSync(path.join(dir, entry.name)).size;
+			count++;
+			totalSize += size;
+
+			const ext = path.extname(entry.name).slice(1);
+			if (ext === "js" || ext === "css") {
+				this.trackBundle(entry.name, size, ext);
+			}
+		}
+		this.metrics.assets = { count, totalSize, totalSizeFormatted: this.formatBytes(totalSize) };
+	}
+
 	// Track memory usage
 	trackMemoryUsage(stage) {
 		const usage = process.memoryUsage();
@@ -146,7 +193,9 @@ class BuildAnalytics {
 	}
 
 	// Complete analytics session
-	completeAnalytics() {
+	completeAnalytics(status = "success") {
+		this.endStep(status === "success" ? "completed" : "failed");
+		this.metrics.status = status;
 		this.metrics.endTime = Date.now();
 		this.metrics.buildTime = this.metrics.endTime - this.metrics.startTime;
 
@@ -169,7 +218,9 @@ class BuildAnalytics {
 		this.saveToHistory();
 
 		// Display results
-		this.displayResults();
+		if (!this.options.silent) {
+			this.displayResults();
+		}
 
 		return this.metrics;
 	}
@@ -274,7 +325,7 @@ class BuildAnalytics {
 		}
 
 		// Memory optimization
-		const peakMemory = Math.max(...Object.values(this.metrics.memoryUsage).map(usage => usage.heapUsedMB));
+		const peakMemory = Math.max(0, ...Object.values(this.metrics.memoryUsage).map(usage => usage.heapUsedMB));
 
 		if (peakMemory > 500) {
 			suggestions.push({
@@ -434,6 +485,28 @@ class BuildAnalytics {
 		return "stable";
 	}
 
+	getPerformanceRecommendation(score) {
+		if (score >= 80) return "Build performance is healthy";
+		if (score >= 60) return "Build performance is acceptable; review cache efficiency and warnings";
+		return "Build performance needs attention; check slow steps and cache invalidation";
+	}
+
+	getBundleTrend(bundleName) {
+		const sizes = this.history.map(h => h.bundleSizes?.[bundleName]?.size).filter(size => typeof size === "number");
+		return this.calculateTrend(sizes);
+	}
+
+	getBundleRecommendation(bundleName, size) {
+		if (size > this.thresholds.bundleSizeError) return "Split this bundle or lazy-load part of it";
+		if (size > this.thresholds.bundleSizeWarning) return "Consider code splitting or minification";
+		return null;
+	}
+
+	getTotalSizeTrend(totalSize) {
+		const sizes = this.history.map(h => this.getTotalBundleSize(h));
+		return this.calculateTrend([...sizes, totalSize]);
+	}
+
 	getTotalBundleSize(historyEntry) {
 		if (!historyEntry.bundleSizes) return 0;
 		return Object.values(historyEntry.bundleSizes).reduce((sum, bundle) => sum + bundle.size, 0);
@@ -17,6 +17,7 @@ import { spawn } from "child_process";
 import { glob } from "glob";
 import fs from "fs";
 import path from "path";
+import readline from "readline";
 import chokidar from "chokidar";
 import { performance } from "perf_hooks";
 import { BuildCache, PerformanceTracker, ParallelFileProcessor, FileAnalyzer } from "../optimized-utils.js";
@@ -208,6 +209,8 @@ class DevServerManager extends EventEmitter {
 		this.networkOptimizations = new Map();
 		this.fileWatchEfficiency = { watched: 0, ignored: 0, processed: 0 };
 		this.startTime = Date.now();
+		// The TUI passes its memory_threshold_mb setting through the environment
+		this.memoryThreshold = (Number(process.env.MEMORY_THRESHOLD_MB) || 500) * 1024 * 1024;
 
 		this.setupMemoryMonitoring();
 		this.setupNetworkOptimizations();
@@ -220,9 +223,13 @@ class DevServerManager extends EventEmitter {
 			this.memoryUsage.current = usage.heapUsed;
 			this.memoryUsage.peak = Math.max(this.memoryUsage.peak, usage.heapUsed);
 
+			this.emit("memory-sample", {
+				current: this.formatBytes(usage.heapUsed),
+				peak: this.formatBytes(this.memoryUsage.peak)
+			});
+
 			// Emit warning if memory usage is high
-			if (usage.heapUsed > 500 * 1024 * 1024) {
-				// 500MB
+			if (usage.heapUsed > this.memoryThreshold) {
 				this.emit("memory-warning", {
 					current: this.formatBytes(usage.heapUsed),
 					peak: this.formatBytes(this.memoryUsage.peak)
@@ -269,6 +276,30 @@ class DevServerManager extends EventEmitter {
 		};
 	}
 
+	// The TUI sends commands such as "gc" through stdin when the watch runs out of memory
+	listenForCommands() {
+		if (process.env.TUI_MODE !== "true" || process.stdin.isTTY || this.commands) return;
+		this.commands = readline.createInterface({ input: process.stdin });
+		this.commands.on("line", line => {
+			if (line.trim() === "gc") this.collectGarbage();
+		});
+	}
+
+	collectGarbage() {
+		if (!global.gc) {
+			this.buildEngine.emit("log", { level: "warning", message: "🧹 Garbage collection unavailable: start node with --expose-gc" });
+			return;
+		}
+		const before = process.memoryUsage().heapUsed;
+		global.gc();
+		const after = process.memoryUsage().heapUsed;
+		this.memoryUsage.current = after;
+		this.buildEngine.emit("log", {
+			level: "info",
+			message: `🧹 Garbage collection freed ${this.formatBytes(Math.max(0, before - after))} (heap ${this.formatBytes(after)})`
+		});
+	}
+
 	formatBytes(bytes) {
 		if (bytes === 0) return "0 B";
 		const k = 1024;
@@ -281,6 +312,9 @@ class DevServerManager extends EventEmitter {
 		if (this.memoryInterval) {
 			clearInterval(this.memoryInterval);
 		}
+		if (this.commands) {
+			this.commands.close();
+		}
 	}
 }
 
@@ -322,6 +356,7 @@ export class BuildEngine extends EventEmitter {
 		this.hotReloadManager.on("hot-reload", data => this.emit("hot-reload", data));
 
 		// Forward dev server events
+		this.devServerManager.on("memory-sample", data => this.emit("memory-sample", data));
 		this.devServerManager.on("memory-warning", data => {
 			this.emit("log", {
 				level: "warning",
@@ -536,6 +571,7 @@ export class BuildEngine extends EventEmitter {
 
 	async copyFiles(options) {
 		this.performance.start("file-analysis");
+		this.performance.metrics.fileDetails = [];
 		const files = await glob("**/*", { cwd: SRC_DIR, nodir: true });
 
 		// Enhanced file filtering with optimization patterns
@@ -587,6 +623,7 @@ export class BuildEngine extends EventEmitter {
 					skippedFiles.push({ path: fullPath, reason: "cache-hit" });
 					this.stats.cacheHits++;
 					this.performance.metrics.fileStats.fromCache++;
+					this.performance.metrics.fileDetails.push({ path: path.relative(path.dirname(SRC_DIR), fullPath), processingTime: 0, fromCache: true });
 				}
 			} catch (error) {
 				this.emit("log", { level: "warning", message: `Skipping invalid file: ${file} - ${error.message}` });
@@ -596,6 +633,7 @@ export class BuildEngine extends EventEmitter {
 		this.performance.end("file-analysis");
 		this.stats.filesSkipped = skippedFiles.length;
 		this.performance.metrics.fileStats.total = validFiles.length;
+		this.performance.metrics.fileStats.cacheMisses = filesToCopy.length;
 
 		// Phase 2A: Enhanced analysis reporting with dependency insights
 		const cacheStats = this.cache.getCacheStats();
@@ -640,8 +678,14 @@ export class BuildEngine extends EventEmitter {
 				const { destFolder } = getDestination(filePath);
 				if (!destFolder) return { copied: false, reason: "no-destination" };
 
+				const started = performance.now();
 				await fs.promises.mkdir(destFolder, { recursive: true });
 				await fs.promises.copyFile(filePath, destPath || fileInfo.destPath);
+				this.performance.metrics.fileDetails.push({
+					path: path.relative(path.dirname(SRC_DIR), filePath),
+					processingTime: performance.now() - started,
+					fromCache: false
+				});
 
 				// Track optimization opportunities
 				if (analysis.optimization && analysis.optimization.length > 0) {
@@ -920,6 +964,7 @@ export class BuildEngine extends EventEmitter {
 
 		// Record baseline memory usage
 		this.devServerManager.memoryUsage.baseline = process.memoryUsage().heapUsed;
+		this.devServerManager.listenForCommands();
 
 		// Initial build
 		await this.build({ ...options, optimize: false }, context);
@@ -88,9 +88,18 @@ program
 				} else if (log.message.includes("✅ Updated:")) {
 					const fileName = log.message.split("Updated: ")[1];
 					visual.showFileChange(fileName, "copied");
+				} else if (log.message.includes("⚠️ High memory usage:") || log.message.includes("🧹")) {
+					console.log(log.message);
 				}
 			});
 
+			// The TUI charts the heap over time from these samples
+			if (process.env.TUI_MODE === "true") {
+				engine.on("memory-sample", ({ current, peak }) => {
+					console.log(`🧠 Heap usage: ${current} (peak: ${peak})`);
+				});
+			}
+
 			// Shopify-specific event handlers
 			if (options.shopify && !options.noShopify) {
 				engine.on("shopify:ready", () => {
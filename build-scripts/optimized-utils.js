@@ -336,8 +336,11 @@ class PerformanceTracker {
 				processed: 0,
 				skipped: 0,
 				errors: 0,
-				fromCache: 0
+				fromCache: 0,
+				cacheMisses: 0
 			},
+			// Per-file timings of the last build, read back by the TUI adapter
+			fileDetails: [],
 			optimizations: [],
 			buildHistory: [],
 			averageBuildTime: 0,
@@ -21,6 +21,7 @@ import { spawn } from "child_process";
 import { EventEmitter } from "events";
 import path from "path";
 import fs from "fs";
+import BuildAnalytics from "../build-utilities/build-analytics.js";
 
 class TUIBuildAdapter extends EventEmitter {
 	constructor() {
@@ -30,7 +31,8 @@ class TUIBuildAdapter extends EventEmitter {
 		this.steps = [
 			{ name: "File Copy", phase: "copy", weight: 40 },
 			{ name: "Tailwind CSS", phase: "styles", weight: 30 },
-			{ name: "Vite Build", phase: "scripts", weight: 30 }
+			{ name: "Vite Build", phase: "scripts", weight: 30 },
+			{ name: "Optimization", phase: "optimize", weight: 0 }
 		];
 		this.currentStep = 0;
 		this.totalProgress = 0;
@@ -39,6 +41,58 @@ class TUIBuildAdapter extends EventEmitter {
 		this.cacheReported = false;
 		this.optimizationsReported = false;
 		this.filesReported = false;
+		this.analytics = null;
+	}
+
+	/**
+	 * Record the build in analytics-data so the TUI history can show it.
+	 * Analytics failures never fail the build.
+	 */
+	startAnalytics(options) {
+		try {
+			this.analytics = new BuildAnalytics({
+				silent: this.isTUIMode || !options.report,
+				profile: options.profile || "custom"
+			});
+			this.analytics.startAnalytics();
+			this.analytics.trackMemoryUsage("start");
+			this.analytics.startStep("init");
+		} catch (error) {
+			this.analytics = null;
+		}
+	}
+
+	completeAnalytics(success, stats) {
+		if (!this.analytics) return;
+		try {
+			const metrics = this.analytics.metrics;
+			if (stats.fileDetails.length > 0) {
+				for (const file of stats.fileDetails) {
+					this.analytics.trackFileProcessed(file.path, file.processingTime, file.fromCache);
+				}
+			} else {
+				// Builds that skip the engine's copy step leave no per-file data
+				metrics.filesProcessed = stats.filesCopied;
+				metrics.cacheHits = stats.cacheHits;
+				metrics.cacheMisses = stats.cacheMisses;
+			}
+			this.analytics.trackMemoryUsage("end");
+			this.analytics.trackAssetDirectory(path.join(process.cwd(), "Curalife-Theme-Build", "assets"));
+			this.analytics.completeAnalytics(success ? "success" : "failed");
+			this.outputTUIData("report", { buildId: metrics.buildId, status: metrics.status });
+		} catch (error) {
+			this.outputTUIData("log", {
+				level: "warning",
+				message: `Failed to save build report: ${error.message}`,
+				source: "build"
+			});
+		}
+	}
+
+	trackStep(stepIndex) {
+		if (this.analytics && stepIndex < this.steps.length) {
+			this.analytics.startStep(this.steps[stepIndex].name);
+		}
 	}
 
 	outputTUIData(type, data) {
@@ -87,10 +141,33 @@ class TUIBuildAdapter extends EventEmitter {
 	}
 
 	outputStats(stats) {
-		this.outputTUIData("stats", stats);
+		// Per-file details go to the build report, not the status line
+		const { fileDetails, ...summary } = stats;
+		this.outputTUIData("stats", summary);
 	}
 
-	async runBuild(withReport = false) {
+	/**
+	 * Build the command line for the selected build options.
+	 * Auto-optimized builds go through the integrated workflow; everything
+	 * else calls the unified CLI directly so each flag reaches the engine.
+	 */
+	getBuildCommand(options) {
+		let args;
+		if (options.autoOptimize) {
+			args = ["build-scripts/workflow-integration.js", "build", "--auto-optimize"];
+			if (options.assets === false) args.push("--no-assets");
+			if (options.report) args.push("--verbose");
+		} else {
+			args = ["build-scripts/curalife.js", "build"];
+			if (options.assets === true) args.push("--assets");
+			if (options.assets === false) args.push("--no-assets");
+			if (options.cache === false) args.push("--no-cache");
+			if (options.report) args.push("--analyze");
+		}
+		return { command: process.execPath, args };
+	}
+
+	async runBuild(options = {}) {
 		try {
 			this.outputTUIData("log", {
 				level: "info",
@@ -99,16 +176,15 @@ class TUIBuildAdapter extends EventEmitter {
 			});
 			this.outputClean("Starting optimized build process...");
 			this.outputProgress("init", 0, "running", "Initializing build system");
+			this.startAnalytics(options);
 
-			// Determine the build command with proper cross-platform handling
-			let command, args;
-			if (process.platform === "win32") {
-				command = "cmd";
-				args = withReport ? ["/c", "npm", "run", "build:report"] : ["/c", "npm", "run", "build"];
-			} else {
-				command = "npm";
-				args = withReport ? ["run", "build:report"] : ["run", "build"];
-			}
+			// Determine the build command; node is invoked directly so no shell is needed on any platform
+			const { command, args } = this.getBuildCommand(options);
+			this.outputTUIData("log", {
+				level: "info",
+				message: `Running: node ${args.join(" ")}`,
+				source: "build"
+			});
 
 			const buildProcess = spawn(command, args, {
 				stdio: ["pipe", "pipe", "pipe"],
@@ -119,6 +195,9 @@ class TUIBuildAdapter extends EventEmitter {
 				throw new Error("Failed to spawn build process");
 			}
 
+			// The engine starts by copying files, which the output parser treats as the initial step
+			this.trackStep(0);
+
 			let lastProgress = 0;
 			let currentPhase = "";
 
@@ -151,9 +230,11 @@ class TUIBuildAdapter extends EventEmitter {
 			});
 
 			buildProcess.on("close", code => {
+				const stats = this.getCompletionStats();
+				this.completeAnalytics(code === 0, stats);
 				if (code === 0) {
 					this.outputProgress("complete", 100, "completed", "Build completed successfully!");
-					this.outputStats(this.getCompletionStats());
+					this.outputStats(stats);
 					this.outputTUIData("log", {
 						level: "success",
 						message: `Build completed in ${Date.now() - this.startTime}ms`,
@@ -210,6 +291,9 @@ class TUIBuildAdapter extends EventEmitter {
 		} else if (cleanOutput.includes("Building scripts") || cleanOutput.includes("Vite")) {
 			this.setCurrentStep(2, "Building with Vite...");
 			this.outputClean("Building scripts with Vite...", "info");
+		} else if (cleanOutput.includes("Optimizing assets")) {
+			this.setCurrentStep(3, "Optimizing assets...");
+			this.outputClean("Optimizing assets...", "info");
 		}
 
 		// Look for completion indicators
@@ -263,6 +347,8 @@ class TUIBuildAdapter extends EventEmitter {
 			this.setCurrentStepClean(1, "Building styles with Tailwind CSS...");
 		} else if ((cleanOutput.includes("Building scripts") || cleanOutput.includes("Vite")) && this.currentStep !== 2) {
 			this.setCurrentStepClean(2, "Building scripts with Vite...");
+		} else if (cleanOutput.includes("Optimizing assets") && this.currentStep !== 3) {
+			this.setCurrentStepClean(3, "Optimizing assets...");
 		}
 
 		// Look for completion indicators (only show once)
@@ -294,6 +380,7 @@ class TUIBuildAdapter extends EventEmitter {
 
 	setCurrentStepClean(stepIndex, message) {
 		this.currentStep = stepIndex;
+		this.trackStep(stepIndex);
 		this.outputClean(message, "info");
 	}
 
@@ -315,6 +402,7 @@ class TUIBuildAdapter extends EventEmitter {
 			}
 
 			this.currentStep = stepIndex;
+			this.trackStep(stepIndex);
 			if (stepIndex < this.steps.length) {
 				const step = this.steps[stepIndex];
 				this.outputProgress(step.phase, 0, "running", message);
@@ -357,21 +445,22 @@ class TUIBuildAdapter extends EventEmitter {
 			duration,
 			filesCopied: 0,
 			cacheHits: 0,
-			optimizations: 0
+			cacheMisses: 0,
+			optimizations: 0,
+			fileDetails: []
 		};
 
 		try {
-			const cachePath = path.join(process.cwd(), "build-scripts/cache/.build-cache.json");
-			if (fs.existsSync(cachePath)) {
-				const cacheData = JSON.parse(fs.readFileSync(cachePath, "utf8"));
-				stats.cacheHits = Object.keys(cacheData.files || {}).length;
-			}
-
+			// The engine saves its file stats, including every file it copied or
+			// found in the cache, at the end of each build
 			const perfPath = path.join(process.cwd(), "build-scripts/cache/.build-performance.json");
 			if (fs.existsSync(perfPath)) {
 				const perfData = JSON.parse(fs.readFileSync(perfPath, "utf8"));
 				stats.filesCopied = perfData.fileStats?.processed || 0;
+				stats.cacheHits = perfData.fileStats?.fromCache || 0;
+				stats.cacheMisses = perfData.fileStats?.cacheMisses || 0;
 				stats.optimizations = perfData.optimizations?.length || 0;
+				stats.fileDetails = perfData.fileDetails || [];
 			}
 		} catch (error) {
 			// Ignore errors reading stats files
@@ -397,10 +486,21 @@ class TUIBuildAdapter extends EventEmitter {
 	}
 }
 
+function getArgValue(name) {
+	const index = process.argv.indexOf(name);
+	return index !== -1 && index + 1 < process.argv.length ? process.argv[index + 1] : undefined;
+}
+
 // Main execution
 async function main() {
 	const adapter = new TUIBuildAdapter();
-	const withReport = process.argv.includes("--report");
+	const options = {
+		report: process.argv.includes("--report"),
+		assets: process.argv.includes("--assets") ? true : process.argv.includes("--no-assets") ? false : undefined,
+		cache: !process.argv.includes("--no-cache"),
+		autoOptimize: process.argv.includes("--auto-optimize"),
+		profile: getArgValue("--profile")
+	};
 
 	if (!adapter.isTUIMode) {
 		console.log("\n🔨 Curalife Build Adapter");
@@ -408,7 +508,7 @@ async function main() {
 	}
 
 	try {
-		await adapter.runBuild(withReport);
+		await adapter.runBuild(options);
 		if (!adapter.isTUIMode) {
 			console.log("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━");
 		}
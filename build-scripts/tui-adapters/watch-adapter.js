@@ -21,6 +21,7 @@ import { spawn } from "child_process";
 import { EventEmitter } from "events";
 import path from "path";
 import fs from "fs";
+import readline from "readline";
 
 class TUIWatchAdapter extends EventEmitter {
 	constructor(isShopify = false) {
@@ -137,6 +138,28 @@ class TUIWatchAdapter extends EventEmitter {
 		});
 	}
 
+	/**
+	 * Forward TUI commands such as "gc" from stdin to the watch engine,
+	 * which is reached through npm's inherited stdin
+	 */
+	listenForCommands() {
+		if (!this.isTUIMode || process.stdin.isTTY || this.commands) return;
+		this.commands = readline.createInterface({ input: process.stdin });
+		this.commands.on("line", line => {
+			if (line.trim() === "gc" && this.watchProcess) {
+				this.watchProcess.stdin.write("gc\n");
+				this.outputLog("info", "Requested garbage collection from the watch engine", "memory");
+			}
+		});
+	}
+
+	stopListeningForCommands() {
+		if (this.commands) {
+			this.commands.close();
+			this.commands = null;
+		}
+	}
+
 	outputLog(level, message, source = "watch") {
 		this.outputTUIData("log", {
 			level,
@@ -181,6 +204,7 @@ class TUIWatchAdapter extends EventEmitter {
 
 			// Store the process for cleanup
 			this.watchProcess = watchProcess;
+			this.listenForCommands();
 
 			// Parse stdout for watch information
 			watchProcess.stdout.on("data", data => {
@@ -224,6 +248,7 @@ class TUIWatchAdapter extends EventEmitter {
 			watchProcess.on("close", code => {
 				this.stats.isActive = false;
 				this.watchProcess = null;
+				this.stopListeningForCommands();
 				this.outputWatchStatus();
 
 				if (code === 0) {
@@ -255,6 +280,7 @@ class TUIWatchAdapter extends EventEmitter {
 			watchProcess.on("error", error => {
 				this.stats.isActive = false;
 				this.watchProcess = null;
+				this.stopListeningForCommands();
 				this.outputWatchStatus();
 				this.outputTUIData("log", {
 					level: "error",
@@ -286,6 +312,22 @@ class TUIWatchAdapter extends EventEmitter {
 		// Remove ANSI codes for parsing
 		const cleanOutput = output.replace(/\x1b\[[0-9;]*m/g, "");
 
+		// Heap samples and warnings from the engine's dev server monitor, in the format unified-adapter.js parses
+		for (const line of cleanOutput.split("\n")) {
+			const sampleMatch = line.match(/🧠 Heap usage: ([^(]+) \(peak: ([^)]+)\)/);
+			const warningMatch = line.match(/⚠️ High memory usage: ([^(]+) \(peak: ([^)]+)\)/);
+			const memoryMatch = sampleMatch || warningMatch;
+			if (memoryMatch) {
+				const [, current, peak] = memoryMatch;
+				this.outputTUIData(sampleMatch ? "memory_sample" : "memory_warning", {
+					current: current.trim(),
+					peak: peak.trim()
+				});
+			} else if (line.includes("🧹")) {
+				this.outputLog(line.includes("unavailable") ? "warning" : "info", line.trim(), "memory");
+			}
+		}
+
 		// Look for file watching status - enhanced patterns for Shopify CLI and Vite
 		const watchingPatterns = [
 			/watching\s+(\d+)\s+files?/i,
@@ -0,0 +1,223 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// analyticsTabs are the tabs of the analytics dashboard, switched with the number keys
+var analyticsTabs = []string{"Overview", "Performance", "System"}
+
+// GetAnalytics summarizes the build history and the latest process sample
+func (b *Backend) GetAnalytics() (AnalyticsData, error) {
+	history, err := loadBuildHistory()
+	data := AnalyticsData{
+		Overview:    overviewFromHistory(history),
+		Performance: performanceFromHistory(history),
+		System:      b.GetSystemSample().Total,
+	}
+	for _, job := range b.jobs.List() {
+		if job.Kind == "watch" {
+			data.Overview.TotalWatches++
+		}
+	}
+	return data, err
+}
+
+func overviewFromHistory(history []BuildReport) OverviewData {
+	overview := OverviewData{TotalBuilds: len(history)}
+	var total time.Duration
+	for _, report := range history {
+		if report.Outcome() == "success" {
+			overview.Success++
+			total += time.Duration(report.Metrics.BuildTime) * time.Millisecond
+		} else {
+			overview.Failures++
+		}
+	}
+	if overview.Success > 0 {
+		overview.AvgBuildTime = total / time.Duration(overview.Success)
+	}
+	// History is newest first
+	if len(history) > 0 {
+		overview.LastBuild = history[0].Started()
+	}
+	return overview
+}
+
+func performanceFromHistory(history []BuildReport) PerformanceData {
+	var performance PerformanceData
+	var durations []time.Duration
+	var files, hits, lookups int
+	var optimization []float64
+	for _, report := range history {
+		if report.Outcome() != "success" {
+			continue
+		}
+		metrics := report.Metrics
+		durations = append(durations, time.Duration(metrics.BuildTime)*time.Millisecond)
+		files += metrics.FilesProcessed
+		hits += metrics.CacheHits
+		lookups += metrics.CacheHits + metrics.CacheMisses
+		if duration, ok := stepDurations(metrics.Steps)["Optimization"]; ok {
+			optimization = append(optimization, duration)
+		}
+	}
+	if len(durations) == 0 {
+		return performance
+	}
+
+	sort.Slice(durations, func(i, j int) bool { return durations[i] < durations[j] })
+	var total time.Duration
+	for _, duration := range durations {
+		total += duration
+	}
+	performance.AvgBuildTime = total / time.Duration(len(durations))
+	performance.FastestBuild = durations[0]
+	performance.SlowestBuild = durations[len(durations)-1]
+	if total > 0 {
+		performance.FilesPerSecond = float64(files) / total.Seconds()
+	}
+	if lookups > 0 {
+		performance.CacheHitRate = float64(hits) / float64(lookups)
+	}
+	if len(optimization) > 0 {
+		performance.OptimizationTime = time.Duration(medianOf(optimization)) * time.Millisecond
+	}
+	return performance
+}
+
+func (m Model) openAnalytics() Model {
+	m.state = StateAnalytics
+	data, err := m.backend.GetAnalytics()
+	m.analytics = data
+	if err != nil {
+		m.statusMessage = fmt.Sprintf("❌ %v", err)
+	}
+	return m
+}
+
+func (m Model) handleAnalyticsKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "ctrl+c", "q":
+		m.backend.Cleanup()
+		return m, tea.Quit
+	case "esc":
+		m.state = StateMenu
+	case "1", "2", "3":
+		m.analyticsTab = int(msg.String()[0] - '1')
+	case "right", "l", "tab":
+		m.analyticsTab = (m.analyticsTab + 1) % len(analyticsTabs)
+	case "left", "h", "shift+tab":
+		m.analyticsTab = (m.analyticsTab + len(analyticsTabs) - 1) % len(analyticsTabs)
+	case "r":
+		m.statusMessage = ""
+		return m.openAnalytics(), nil
+	}
+	return m, nil
+}
+
+func (m Model) renderAnalytics() string {
+	s := "\n"
+	s += titleStyle.Render("📊 ANALYTICS") + "\n\n"
+
+	tabs := ""
+	for i, name := range analyticsTabs {
+		label := fmt.Sprintf(" %d %s ", i+1, name)
+		if i == m.analyticsTab {
+			tabs += selectedStyle.Render(label)
+		} else {
+			tabs += normalStyle.Render(label)
+		}
+	}
+	s += tabs + "\n\n"
+
+	switch analyticsTabs[m.analyticsTab] {
+	case "Overview":
+		s += m.renderAnalyticsOverview()
+	case "Performance":
+		s += m.renderAnalyticsPerformance()
+	case "System":
+		s += m.renderAnalyticsSystem()
+	}
+
+	if m.statusMessage != "" {
+		s += "\n" + infoStyle.Render(m.statusMessage) + "\n"
+	}
+
+	s += "\n" + helpStyle.Render("1-3/←/→: switch tab • r: refresh • esc: return to menu") + "\n"
+	return s
+}
+
+func (m Model) renderAnalyticsOverview() string {
+	overview := m.analytics.Overview
+	if overview.TotalBuilds == 0 {
+		return detailStyle.Render("No builds recorded yet — reports appear in analytics-data after each TUI build") + "\n"
+	}
+	s := statsStyle.Render("📈 Builds:") + "\n"
+	s += detailStyle.Render(fmt.Sprintf("  Total:          %d", overview.TotalBuilds)) + "\n"
+	s += detailStyle.Render(fmt.Sprintf("  Succeeded:      %d", overview.Success)) + "\n"
+	s += detailStyle.Render(fmt.Sprintf("  Failed:         %d", overview.Failures)) + "\n"
+	s += detailStyle.Render(fmt.Sprintf("  Average time:   %s", formatMillis(overview.AvgBuildTime.Milliseconds()))) + "\n"
+	s += detailStyle.Render(fmt.Sprintf("  Last build:     %s", overview.LastBuild.Format("2006-01-02 15:04"))) + "\n"
+	s += detailStyle.Render(fmt.Sprintf("  Watch sessions: %d (this run)", overview.TotalWatches)) + "\n"
+	return s
+}
+
+func (m Model) renderAnalyticsPerformance() string {
+	performance := m.analytics.Performance
+	if performance.AvgBuildTime == 0 {
+		return detailStyle.Render("No successful builds recorded yet") + "\n"
+	}
+	s := statsStyle.Render("⚡ Successful builds:") + "\n"
+	s += detailStyle.Render(fmt.Sprintf("  Average:        %s", formatMillis(performance.AvgBuildTime.Milliseconds()))) + "\n"
+	s += detailStyle.Render(fmt.Sprintf("  Fastest:        %s", formatMillis(performance.FastestBuild.Milliseconds()))) + "\n"
+	s += detailStyle.Render(fmt.Sprintf("  Slowest:        %s", formatMillis(performance.SlowestBuild.Milliseconds()))) + "\n"
+	s += detailStyle.Render(fmt.Sprintf("  Files/second:   %.1f", performance.FilesPerSecond)) + "\n"
+	s += detailStyle.Render(fmt.Sprintf("  Cache hit rate: %.0f%%", performance.CacheHitRate*100)) + "\n"
+	if performance.OptimizationTime > 0 {
+		s += detailStyle.Render(fmt.Sprintf("  Optimization:   %s (median)", formatMillis(performance.OptimizationTime.Milliseconds()))) + "\n"
+	}
+	return s
+}
+
+// renderAnalyticsSystem shows the live process sample, so it reads the backend on every render
+func (m Model) renderAnalyticsSystem() string {
+	if !m.backend.procAvailable {
+		return detailStyle.Render("Process sampling reads /proc and is only available on Linux") + "\n"
+	}
+	sample := m.backend.GetSystemSample()
+	interval := m.backend.sampleInterval
+
+	s := statsStyle.Render("🖥️  Running jobs:") + "\n"
+	if len(sample.Jobs) == 0 {
+		s += detailStyle.Render("  No jobs running") + "\n"
+	} else {
+		s += detailStyle.Render(fmt.Sprintf("  %-24s %7s %9s %9s %6s %6s", "Job", "CPU", "RSS", "Disk", "Files", "Procs")) + "\n"
+		ids := make([]string, 0, len(sample.Jobs))
+		for id := range sample.Jobs {
+			ids = append(ids, id)
+		}
+		sort.Strings(ids)
+		for _, id := range append(ids, "total") {
+			data := sample.Total
+			if id != "total" {
+				data = sample.Jobs[id]
+			}
+			s += normalStyle.Render(fmt.Sprintf("  %-24s %6.0f%% %6.0f MB %4.1f MB/s %6d %6d", truncate(id, 24),
+				data.CPUUsage, data.MemoryUsage, data.DiskUsage, data.OpenFiles, data.ProcessCount)) + "\n"
+		}
+	}
+	s += detailStyle.Render(fmt.Sprintf("  System load: %.2f", sample.Total.SystemLoad)) + "\n\n"
+
+	if peaks := m.backend.GetBuildStatus().Peaks; peaks.ProcessCount > 0 {
+		s += statsStyle.Render("🏔️  Peaks of the last build:") + "\n"
+		s += detailStyle.Render("  "+peaks.Summary()) + "\n\n"
+	}
+
+	s += detailStyle.Render(fmt.Sprintf("Sampled every %s (sample_interval_ms in %s)", interval, settingsFile)) + "\n"
+	return s
+}
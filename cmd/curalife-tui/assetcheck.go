@@ -0,0 +1,197 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// cssURLPattern matches url(...) in stylesheets, quoted or not
+var cssURLPattern = regexp.MustCompile(`url\(\s*['"]?([^'")]+?)['"]?\s*\)`)
+
+// assetRef is a reference to a file the theme expects in assets/
+type assetRef struct {
+	Name string
+	File string
+	Line int
+}
+
+// cssAssetRefs finds the url() references of a stylesheet that point at theme
+// assets: not data URIs, external URLs, fragments or Liquid
+func cssAssetRefs(path, content string) []assetRef {
+	var refs []assetRef
+	for _, match := range cssURLPattern.FindAllStringSubmatchIndex(content, -1) {
+		url := strings.TrimSpace(content[match[2]:match[3]])
+		if url == "" || strings.HasPrefix(url, "data:") || strings.HasPrefix(url, "#") || strings.Contains(url, "//") ||
+			strings.Contains(url, "{{") || strings.HasPrefix(url, "var(") {
+			continue
+		}
+		// The build flattens everything into assets/, so only the file name matters
+		url, _, _ = strings.Cut(url, "?")
+		url, _, _ = strings.Cut(url, "#")
+		refs = append(refs, assetRef{Name: filepath.Base(url), File: path, Line: lineAt(content, match[0])})
+	}
+	return refs
+}
+
+// collectAssetRefs lists the asset_url references in src/liquid and the url()
+// references in src/styles
+func collectAssetRefs(index ThemeIndex) []assetRef {
+	var refs []assetRef
+	for path, fileRefs := range index.Refs {
+		if !strings.HasPrefix(path, "src/") {
+			continue
+		}
+		for _, ref := range fileRefs {
+			if ref.Kind == "asset" {
+				refs = append(refs, assetRef{Name: ref.Name, File: path, Line: ref.Line})
+			}
+		}
+	}
+
+	root := projectRoot()
+	filepath.Walk(filepath.Join(root, "src", "styles"), func(path string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() || filepath.Ext(path) != ".css" {
+			return nil
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil
+		}
+		relative, _ := filepath.Rel(root, path)
+		refs = append(refs, cssAssetRefs(filepath.ToSlash(relative), string(data))...)
+		return nil
+	})
+
+	sort.Slice(refs, func(i, j int) bool {
+		if refs[i].File != refs[j].File {
+			return refs[i].File < refs[j].File
+		}
+		return refs[i].Line < refs[j].Line
+	})
+	return refs
+}
+
+// builtAssets lists the files the build placed in the theme's assets directory
+func builtAssets() ([]string, error) {
+	entries, err := os.ReadDir(filepath.Join(projectRoot(), buildDirName, "assets"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s/assets: %v", buildDirName, err)
+	}
+	var names []string
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			names = append(names, entry.Name())
+		}
+	}
+	return names, nil
+}
+
+// editDistance is the Levenshtein distance between two names
+func editDistance(a, b string) int {
+	previous := make([]int, len(b)+1)
+	current := make([]int, len(b)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		current[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+	return previous[len(b)]
+}
+
+// closestAsset suggests the built asset a misspelled name probably meant: the
+// nearest one with the same extension, a couple of edits away at most
+func closestAsset(name string, built []string) (string, bool) {
+	best, bestDistance := "", 3
+	if len(name) < 8 {
+		bestDistance = 2
+	}
+	for _, candidate := range built {
+		if filepath.Ext(candidate) != filepath.Ext(name) {
+			continue
+		}
+		if distance := editDistance(name, candidate); distance < bestDistance {
+			best, bestDistance = candidate, distance
+		}
+	}
+	return best, best != ""
+}
+
+// checkAssetRefs resolves every reference against the built assets. Shopify
+// serves assets by exact name, so a case mismatch is a 404 like a missing file.
+func checkAssetRefs(refs []assetRef, built []string) []Diagnostic {
+	exact := make(map[string]bool, len(built))
+	folded := make(map[string]string, len(built))
+	for _, name := range built {
+		exact[name] = true
+		folded[strings.ToLower(name)] = name
+	}
+
+	var diagnostics []Diagnostic
+	for _, ref := range refs {
+		if exact[ref.Name] {
+			continue
+		}
+		d := Diagnostic{Severity: "error", Tool: "assets", File: ref.File, Line: ref.Line}
+		if match, ok := folded[strings.ToLower(ref.Name)]; ok {
+			d.Check = "AssetCase"
+			d.Message = fmt.Sprintf("%q only matches %q if case is ignored; asset URLs are case-sensitive", ref.Name, match)
+		} else if match, ok := closestAsset(ref.Name, built); ok {
+			d.Check = "MisspelledAsset"
+			d.Message = fmt.Sprintf("%q is not in assets — did you mean %q?", ref.Name, match)
+		} else {
+			d.Check = "MissingAsset"
+			d.Message = fmt.Sprintf("%q is not in assets after the build", ref.Name)
+		}
+		diagnostics = append(diagnostics, d)
+	}
+	return diagnostics
+}
+
+// CheckAssets resolves the asset references in src against the built assets
+// and replaces the earlier asset diagnostics with the findings
+func (b *Backend) CheckAssets() (int, error) {
+	built, err := builtAssets()
+	if err != nil {
+		return 0, err
+	}
+	diagnostics := checkAssetRefs(collectAssetRefs(buildThemeIndex()), built)
+
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	kept := b.diagnostics[:0]
+	for _, d := range b.diagnostics {
+		if d.Tool != "assets" {
+			kept = append(kept, d)
+		}
+	}
+	b.diagnostics = kept
+	b.addDiagnostics(diagnostics)
+	return len(diagnostics), nil
+}
+
+// checkBuiltAssets runs the asset check after a successful build and reports
+// the outcome in the build log
+func (b *Backend) checkBuiltAssets() {
+	count, err := b.CheckAssets()
+	switch {
+	case err != nil:
+		b.jobs.Log("build", "⚠️  Asset check skipped: "+err.Error())
+	case count > 0:
+		b.jobs.Log("build", fmt.Sprintf("❌ %d broken asset references (see Diagnostics)", count))
+	default:
+		b.jobs.Log("build", "✅ All asset references resolve")
+	}
+}
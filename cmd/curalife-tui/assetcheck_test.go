@@ -0,0 +1,78 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCheckAssetRefs(t *testing.T) {
+	built := []string{"main.css", "product-gallery.js", "logo.svg", "hero-banner.png"}
+	tests := []struct {
+		name      string
+		ref       string
+		wantCheck string // empty when the reference resolves
+	}{
+		{name: "exact match", ref: "product-gallery.js"},
+		{name: "case mismatch", ref: "Logo.svg", wantCheck: "AssetCase"},
+		{name: "one letter off", ref: "product-galery.js", wantCheck: "MisspelledAsset"},
+		{name: "transposed letters", ref: "mian.css", wantCheck: "MisspelledAsset"},
+		{name: "close name with another extension", ref: "main.js", wantCheck: "MissingAsset"},
+		{name: "nothing close", ref: "checkout-upsell.js", wantCheck: "MissingAsset"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			diagnostics := checkAssetRefs([]assetRef{{Name: test.ref, File: "src/liquid/layout/theme.liquid", Line: 4}}, built)
+			if test.wantCheck == "" {
+				if len(diagnostics) != 0 {
+					t.Fatalf("got %v, want no diagnostics", diagnostics)
+				}
+				return
+			}
+			if len(diagnostics) != 1 {
+				t.Fatalf("got %d diagnostics, want 1", len(diagnostics))
+			}
+			d := diagnostics[0]
+			if d.Check != test.wantCheck || d.Tool != "assets" || d.Severity != "error" || d.Line != 4 {
+				t.Errorf("got %s/%s/%s line %d, want assets/%s/error line 4", d.Tool, d.Check, d.Severity, d.Line, test.wantCheck)
+			}
+		})
+	}
+}
+
+func TestClosestAsset(t *testing.T) {
+	built := []string{"main.css", "main-v2.css", "cart.js"}
+	tests := []struct {
+		name string
+		want string
+		ok   bool
+	}{
+		{name: "mainn.css", want: "main.css", ok: true},
+		{name: "main-v3.css", want: "main-v2.css", ok: true},
+		{name: "cat.js", want: "cart.js", ok: true},
+		// Short names only allow a single edit
+		{name: "cxrx.js"},
+		{name: "styles.css"},
+	}
+	for _, test := range tests {
+		got, ok := closestAsset(test.name, built)
+		if got != test.want || ok != test.ok {
+			t.Errorf("closestAsset(%q) = %q, %v, want %q, %v", test.name, got, ok, test.want, test.ok)
+		}
+	}
+}
+
+func TestCSSAssetRefs(t *testing.T) {
+	css := `.hero { background: url("../images/hero-banner.png"); }
+.icon { background: url(icons/arrow.svg?v=2#top); }
+.inline { background: url(data:image/svg+xml;base64,AAAA); }
+.cdn { background: url('https://cdn.shopify.com/x.png'); }
+.liquid { background: url({{ 'x.png' | asset_url }}); }
+.var { background: url(var(--image)); }`
+	want := []assetRef{
+		{Name: "hero-banner.png", File: "src/styles/hero.css", Line: 1},
+		{Name: "arrow.svg", File: "src/styles/hero.css", Line: 2},
+	}
+	if got := cssAssetRefs("src/styles/hero.css", css); !reflect.DeepEqual(got, want) {
+		t.Errorf("cssAssetRefs = %v, want %v", got, want)
+	}
+}
@@ -1,15 +1,15 @@
 package main
 
 import (
-	"bufio"
 	"encoding/json"
 	"fmt"
+	"io"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"runtime"
 	"strings"
 	"sync"
-	"syscall"
 	"time"
 )
 
@@ -29,25 +29,94 @@ type WatchStatus struct {
 	Mode          string     `json:"mode"`
 }
 
+// ChangeEntry represents a single file change reported by the watch adapter
+type ChangeEntry struct {
+	Timestamp time.Time `json:"timestamp"`
+	Action    string    `json:"action"`
+	File      string    `json:"file"`
+	// Impact is filled in once the render graph has been searched for the file
+	Impact *ChangeImpact `json:"impact,omitempty"`
+}
+
+// RecentChange mirrors the recentChanges entries sent with watch_status
+type RecentChange struct {
+	Files     []string `json:"files"`
+	Timestamp int64    `json:"timestamp"`
+	Count     int      `json:"count"`
+}
+
+// Limits for the in-memory log buffer and change feed
+const (
+	maxLogEntries    = 500
+	maxChangeEntries = 100
+)
+
 // Backend handles process execution and communication
 type Backend struct {
 	mutex       sync.RWMutex
 	watchStatus WatchStatus
 	buildStatus BuildStatus
-	process     *exec.Cmd
-	isWatching  bool
+	jobs        *JobManager
+	logs        []LogEntry
+	changes     []ChangeEntry
+	diagnostics []Diagnostic
+	scriptRuns  []ScriptRun
+	// Job ID of the most recently started npm script
+	lastScript string
+	// Timestamp of the newest recentChanges entry already in the change feed
+	lastRecentChangeAt int64
+	// Latest /proc sample of the job process trees; closing stopSampler ends
+	// sampling and a send on resample takes a sample right away
+	system      SystemSample
+	stopSampler chan struct{}
+	stopOnce    sync.Once
+	resample    chan struct{}
+	// Whether /proc can be sampled and how often, fixed when the backend starts
+	procAvailable  bool
+	sampleInterval time.Duration
+	// Watch engine memory and the stdin used to send it commands such as "gc"
+	memory     MemoryStatus
+	watchStdin io.WriteCloser
+	// Latest Theme Check report
+	themeCheck ThemeCheckRun
+	// Lints files named in watch change events when lint_on_change is set
+	linter       *ChangeLinter
+	lintOnChange bool
+	// Why the last Lighthouse run of each form factor failed, if it did
+	lighthouseErrors map[string]error
+	// Recorded Lighthouse runs and baselines, reloaded when a run finishes
+	lighthouse LighthouseHistory
+	// Render graph of the theme, rebuilt when Liquid files change
+	graph      *ThemeGraph
+	graphMutex sync.Mutex
+	// Output manifest taken before the current build and the last build's changes to it
+	outputBefore OutputManifest
+	outputDiff   *OutputDiff
 }
 
 // BuildStatus represents the current build state
 type BuildStatus struct {
-	IsRunning     bool   `json:"is_running"`
-	Progress      int    `json:"progress"`
-	CurrentStep   string `json:"current_step"`
-	Message       string `json:"message"`
-	FilesCopied   int    `json:"files_copied"`
-	Duration      int64  `json:"duration"`
-	CacheHits     int    `json:"cache_hits"`
-	Optimizations int    `json:"optimizations"`
+	IsRunning     bool              `json:"is_running"`
+	Progress      int               `json:"progress"`
+	CurrentStep   string            `json:"current_step"`
+	Message       string            `json:"message"`
+	FilesCopied   int               `json:"files_copied"`
+	Duration      int64             `json:"duration"`
+	CacheHits     int               `json:"cache_hits"`
+	Optimizations int               `json:"optimizations"`
+	Options       BuildOptions      `json:"options"`
+	ReportID      string            `json:"report_id"`
+	Regression    *RegressionResult `json:"regression,omitempty"`
+	// Step tracking for step-weighted progress and the ETA
+	StartedAt     time.Time         `json:"started_at"`
+	StepName      string            `json:"step_name"`
+	StepProgress  int               `json:"step_progress"`
+	StepStartedAt time.Time         `json:"step_started_at"`
+	Estimate      BuildEstimate     `json:"-"`
+	// Steps is the timeline of every step seen so far, oldest first
+	Steps         []StepSpan        `json:"steps"`
+	// Peaks of the build's own process tree
+	Peaks         SystemData        `json:"peaks"`
 }
 
 // TUI Data structure for parsing JSON output
@@ -83,11 +152,20 @@ type TUIData struct {
 	ElapsedMs    *int64  `json:"elapsedMs,omitempty"`
 	Level        *string `json:"level,omitempty"`
 	Source       *string `json:"source,omitempty"`
+	// File change fields
+	Action        *string        `json:"action,omitempty"`
+	FileName      *string        `json:"fileName,omitempty"`
+	RecentChanges []RecentChange `json:"recentChanges,omitempty"`
+	// Build report fields
+	BuildID *string `json:"buildId,omitempty"`
+	// Memory sample and warning fields
+	Current *string `json:"current,omitempty"`
+	Peak    *string `json:"peak,omitempty"`
 }
 
 // NewBackend creates a new backend instance
 func NewBackend() *Backend {
-	return &Backend{
+	b := &Backend{
 		watchStatus: WatchStatus{
 			IsActive:     false,
 			FilesWatched: 0,
@@ -99,8 +177,17 @@ func NewBackend() *Backend {
 			IsRunning: false,
 			Progress:  0,
 		},
-		isWatching: false,
+		jobs:        NewJobManager(),
+		stopSampler: make(chan struct{}),
+		resample:    make(chan struct{}, 1),
 	}
+	settings := loadSettings()
+	b.memory.configure(settings)
+	b.linter = NewChangeLinter(settings.lintDebounce(), b.stopSampler, b.lintResult)
+	b.procAvailable = procAvailable()
+	b.sampleInterval = settings.sampleInterval()
+	go b.runSampler(b.sampleInterval)
+	return b
 }
 
 // Initialize sets up the backend
@@ -108,51 +195,80 @@ func (b *Backend) Initialize() error {
 	return nil
 }
 
-// StartBuild begins a build process
-func (b *Backend) StartBuild() error {
+// StartBuild begins a build process with the given options
+func (b *Backend) StartBuild(options BuildOptions) error {
+	if b.jobs.IsRunning("build") {
+		return fmt.Errorf("build is already running")
+	}
+	// Record the output tree so the build's changes to it can be listed
+	// afterwards. Hashing it takes a while on a large theme, so it happens
+	// before taking the lock the screens read through.
+	outputBefore, snapshotErr := snapshotOutput()
+
 	b.mutex.Lock()
 	defer b.mutex.Unlock()
 
-	if b.buildStatus.IsRunning {
+	if b.buildStatus.IsRunning || b.jobs.IsRunning("build") {
 		return fmt.Errorf("build is already running")
 	}
 
+	// Learn step durations from earlier builds for the progress bar and ETA
+	history, _ := loadBuildHistory()
+	estimate := estimateBuild(history, options.Profile)
+
 	// Determine the adapter command (relative to project root since we set cmd.Dir)
 	adapterPath := filepath.Join("build-scripts", "tui-adapters", "build-adapter.js")
-	args := []string{adapterPath, "--tui-mode"}
+	args := append([]string{adapterPath, "--tui-mode"}, options.adapterArgs()...)
 
 	// Set working directory to project root
 	cmd := exec.Command("node", args...)
 	cmd.Dir = filepath.Join("..", "..")
 	cmd.Env = append(os.Environ(), "TUI_MODE=true")
 
-	// Get stdout pipe for reading TUI data
-	stdout, err := cmd.StdoutPipe()
+	b.outputBefore = outputBefore
+
+	// Stderr goes to the diagnostics collector instead of corrupting the screen
+	onStderr, flushStderr := b.stderrHandler("build")
+	err := b.jobs.Start("build", "build", "🔨 Build", cmd, JobHandlers{
+		OnStdout: tuiDataHandler(b.parseBuildTUIData),
+		OnStderr: onStderr,
+		OnExit: func(job Job) {
+			flushStderr()
+			b.finishBuild(job)
+			b.diffBuildOutput()
+			if job.State == JobSucceeded {
+				b.checkBuiltAssets()
+			}
+		},
+	})
 	if err != nil {
-		return fmt.Errorf("failed to get stdout pipe: %v", err)
+		return fmt.Errorf("failed to start build process: %v", err)
+	}
+	if snapshotErr != nil {
+		b.jobs.Log("build", "⚠️  Output snapshot skipped: "+snapshotErr.Error())
 	}
 
-	// Set stderr to avoid blocking
-	cmd.Stderr = os.Stderr
-
-	// Start the process
-	if err := cmd.Start(); err != nil {
-		return fmt.Errorf("failed to start build process: %v", err)
+	// Theme Check, ESLint, schema and asset results stay until their own tools rerun
+	kept := b.diagnostics[:0]
+	for _, d := range b.diagnostics {
+		if !buildTools[d.Tool] {
+			kept = append(kept, d)
+		}
 	}
+	b.diagnostics = kept
 
+	now := time.Now()
 	b.buildStatus = BuildStatus{
 		IsRunning:   true,
 		Progress:    0,
 		CurrentStep: "initializing",
 		Message:     "Starting build...",
+		Options:     options,
+		StartedAt:   now,
+		Estimate:    estimate,
+		Steps:       []StepSpan{{Name: "init", Start: now, Status: StepRunning}},
 	}
 
-	// Start goroutine to read and parse TUI data
-	go b.parseBuildOutput(stdout)
-
-	// Start goroutine to monitor process
-	go b.monitorBuildProcess(cmd)
-
 	return nil
 }
 
@@ -161,38 +277,50 @@ func (b *Backend) StartWatch(isShopify bool) error {
 	b.mutex.Lock()
 	defer b.mutex.Unlock()
 
-	if b.isWatching {
+	if b.jobs.IsRunning("watch") {
 		return fmt.Errorf("watch is already running")
 	}
 
 	// Determine the adapter command (relative to project root since we set cmd.Dir)
 	adapterPath := filepath.Join("build-scripts", "tui-adapters", "watch-adapter.js")
 	args := []string{adapterPath, "--tui-mode"}
+	title := "👁️  Watch"
 	if isShopify {
 		args = append(args, "--shopify")
+		title = "🛍️  Shopify Watch"
 	}
 
 	// Set working directory to project root
 	cmd := exec.Command("node", args...)
 	cmd.Dir = filepath.Join("..", "..")
-	cmd.Env = append(os.Environ(), "TUI_MODE=true")
-
-	// Get stdout pipe for reading TUI data
-	stdout, err := cmd.StdoutPipe()
+	settings := loadSettings()
+	// The engine warns about its heap at the same limit the TUI applies its memory policy at
+	cmd.Env = append(os.Environ(), "TUI_MODE=true", fmt.Sprintf("MEMORY_THRESHOLD_MB=%d", settings.MemoryThreshold))
+	stdin, err := cmd.StdinPipe()
 	if err != nil {
-		return fmt.Errorf("failed to get stdout pipe: %v", err)
+		return fmt.Errorf("failed to create watch stdin: %v", err)
 	}
 
-	// Set stderr to avoid blocking
-	cmd.Stderr = os.Stderr
-
-	// Start the process
-	if err := cmd.Start(); err != nil {
+	// Stderr goes to the diagnostics collector instead of corrupting the screen
+	onStderr, flushStderr := b.stderrHandler("watch")
+	err = b.jobs.Start("watch", "watch", title, cmd, JobHandlers{
+		OnStdout: tuiDataHandler(b.parseTUIData),
+		OnStderr: onStderr,
+		OnExit: func(job Job) {
+			flushStderr()
+			b.mutex.Lock()
+			b.watchStatus.IsActive = false
+			b.mutex.Unlock()
+		},
+	})
+	if err != nil {
 		return fmt.Errorf("failed to start watch process: %v", err)
 	}
-
-	b.process = cmd
-	b.isWatching = true
+	b.watchStdin = stdin
+	b.memory.configure(settings)
+	b.memory.AboveSince = time.Time{}
+	b.lintOnChange = settings.LintOnChange
+	b.linter.Reset()
 
 	// Initialize watch status
 	b.watchStatus = WatchStatus{
@@ -206,26 +334,14 @@ func (b *Backend) StartWatch(isShopify bool) error {
 		b.watchStatus.Mode = "shopify"
 	}
 
-	// Start goroutine to read and parse TUI data
-	go b.parseWatchOutput(stdout)
-
-	// Start goroutine to monitor process
-	go b.monitorWatchProcess()
-
 	return nil
 }
 
-func (b *Backend) parseWatchOutput(stdout interface{}) {
-	if pipe, ok := stdout.(interface{ Read([]byte) (int, error) }); ok {
-		scanner := bufio.NewScanner(pipe)
-		for scanner.Scan() {
-			line := scanner.Text()
-
-			// Parse TUI_DATA lines
-			if strings.HasPrefix(line, "TUI_DATA:") {
-				jsonData := strings.TrimPrefix(line, "TUI_DATA:")
-				b.parseTUIData(jsonData)
-			}
+// tuiDataHandler returns a stdout line handler that passes TUI_DATA payloads to parse
+func tuiDataHandler(parse func(jsonData string)) func(string) {
+	return func(line string) {
+		if strings.HasPrefix(line, "TUI_DATA:") {
+			parse(strings.TrimPrefix(line, "TUI_DATA:"))
 		}
 	}
 }
@@ -279,24 +395,141 @@ func (b *Backend) parseTUIData(jsonData string) {
 		if tuiData.Mode != nil {
 			b.watchStatus.Mode = *tuiData.Mode
 		}
+		for _, change := range tuiData.RecentChanges {
+			if change.Timestamp <= b.lastRecentChangeAt {
+				continue
+			}
+			b.lastRecentChangeAt = change.Timestamp
+			changeTime := time.UnixMilli(change.Timestamp)
+			for _, file := range change.Files {
+				b.appendChange(ChangeEntry{Timestamp: changeTime, Action: "change", File: file})
+				b.lintChanged(file)
+			}
+		}
+	case "file_change":
+		if tuiData.FileName != nil {
+			action := "change"
+			if tuiData.Action != nil {
+				action = *tuiData.Action
+			}
+			b.appendChange(ChangeEntry{Timestamp: time.Now(), Action: action, File: *tuiData.FileName})
+			if action != "unlink" && action != "delete" {
+				b.lintChanged(*tuiData.FileName)
+			}
+		}
+	case "log", "error":
+		b.appendLog(tuiData, "watch")
+	case "memory_sample":
+		if tuiData.Current != nil && tuiData.Peak != nil {
+			b.handleMemorySample(*tuiData.Current, *tuiData.Peak)
+		}
+	case "memory_warning":
+		if tuiData.Current != nil {
+			b.handleMemoryWarning(*tuiData.Current)
+		}
 	}
 }
 
-func (b *Backend) monitorWatchProcess() {
-	if b.process == nil {
+// logEvent stores a message from the TUI itself in the log buffer. Caller must hold the mutex.
+func (b *Backend) logEvent(level, message, source string) {
+	b.appendLog(TUIData{Type: "log", Level: &level, Message: &message, Source: &source}, source)
+}
+
+// appendLog stores a log or error event in the log buffer. Caller must hold the mutex.
+func (b *Backend) appendLog(tuiData TUIData, defaultSource string) {
+	if tuiData.Message == nil {
 		return
 	}
 
-	// Wait for process to complete
-	b.process.Wait()
+	entry := LogEntry{
+		Timestamp: time.Now(),
+		Level:     "info",
+		Message:   *tuiData.Message,
+		Source:    defaultSource,
+	}
+	if tuiData.Type == "error" {
+		entry.Level = "error"
+	}
+	if tuiData.Level != nil {
+		entry.Level = *tuiData.Level
+	}
+	if tuiData.Source != nil {
+		entry.Source = *tuiData.Source
+	}
 
-	b.mutex.Lock()
-	defer b.mutex.Unlock()
+	b.logs = append(b.logs, entry)
+	if len(b.logs) > maxLogEntries {
+		b.logs = b.logs[len(b.logs)-maxLogEntries:]
+	}
+	b.jobs.Log(defaultSource, entry.Message)
 
-	// Mark as inactive when process ends
-	b.isWatching = false
-	b.watchStatus.IsActive = false
-	b.process = nil
+	if entry.Level == "error" || entry.Level == "warning" {
+		b.addDiagnostics(parseDiagnostics(entry.Message))
+	}
+}
+
+// addDiagnostics stores new diagnostics, skipping ones already reported. Caller must hold the mutex.
+func (b *Backend) addDiagnostics(diagnostics []Diagnostic) {
+	for _, d := range diagnostics {
+		duplicate := false
+		for _, existing := range b.diagnostics {
+			if existing.key() == d.key() {
+				duplicate = true
+				break
+			}
+		}
+		if duplicate {
+			continue
+		}
+		b.diagnostics = append(b.diagnostics, d)
+	}
+	if len(b.diagnostics) > maxDiagnostics {
+		b.diagnostics = b.diagnostics[len(b.diagnostics)-maxDiagnostics:]
+	}
+}
+
+// stderrHandler returns a line handler that logs adapter stderr and collects
+// diagnostics from it, keeping code frames together, plus a flush for process exit
+func (b *Backend) stderrHandler(source string) (func(string), func()) {
+	var collector diagnosticCollector
+	onLine := func(line string) {
+		collector.Feed(line)
+
+		b.mutex.Lock()
+		defer b.mutex.Unlock()
+		if strings.TrimSpace(line) != "" {
+			b.logs = append(b.logs, LogEntry{Timestamp: time.Now(), Level: "error", Message: line, Source: source})
+			if len(b.logs) > maxLogEntries {
+				b.logs = b.logs[len(b.logs)-maxLogEntries:]
+			}
+		}
+		b.addDiagnostics(collector.Take())
+	}
+	flush := func() {
+		collector.Flush()
+		b.mutex.Lock()
+		defer b.mutex.Unlock()
+		b.addDiagnostics(collector.Take())
+	}
+	return onLine, flush
+}
+
+// lintChanged queues a changed file for linting. Caller must hold the mutex.
+func (b *Backend) lintChanged(file string) {
+	if b.lintOnChange {
+		// Resolving the file walks src, so it stays off the parser's lock
+		go b.linter.Schedule(file)
+	}
+}
+
+// appendChange stores a file change in the change feed and works out its
+// impact in the background. Caller must hold the mutex.
+func (b *Backend) appendChange(entry ChangeEntry) {
+	b.changes = append(b.changes, entry)
+	if len(b.changes) > maxChangeEntries {
+		b.changes = b.changes[len(b.changes)-maxChangeEntries:]
+	}
+	go b.annotateChange(entry)
 }
 
 // StopWatch stops the watch process gracefully
@@ -304,49 +537,39 @@ func (b *Backend) StopWatch() error {
 	b.mutex.Lock()
 	defer b.mutex.Unlock()
 
-	if !b.isWatching || b.process == nil {
+	if !b.jobs.IsRunning("watch") {
 		return fmt.Errorf("no watch process running")
 	}
-
-	// Try graceful shutdown first (SIGINT)
-	if b.process.Process != nil {
-		if err := b.process.Process.Signal(syscall.SIGINT); err != nil {
-			// If graceful shutdown fails, force kill
-			if killErr := b.process.Process.Kill(); killErr != nil {
-				return fmt.Errorf("failed to stop watch process: %v", killErr)
-			}
-		}
+	if err := b.jobs.Stop("watch"); err != nil {
+		return fmt.Errorf("failed to stop watch process: %v", err)
 	}
 
-	b.isWatching = false
 	b.watchStatus.IsActive = false
-	b.process = nil
-
 	return nil
 }
 
-// StopProcess stops any running process (watch or build)
+// RestartWatch stops the watch, waits for it to exit and starts it again in the same mode
+func (b *Backend) RestartWatch() error {
+	shopify := b.GetWatchStatus().Mode == "shopify"
+	if b.jobs.IsRunning("watch") {
+		if err := b.StopWatch(); err != nil {
+			return err
+		}
+		b.jobs.Wait("watch")
+	}
+	return b.StartWatch(shopify)
+}
+
+// StopProcess stops every running job (watch, build and scripts)
 func (b *Backend) StopProcess() error {
 	b.mutex.Lock()
 	defer b.mutex.Unlock()
 
 	var errs []string
-
-	// Stop watch process if running
-	if b.isWatching && b.process != nil {
-		if b.process.Process != nil {
-			// Try graceful shutdown first
-			if err := b.process.Process.Signal(syscall.SIGINT); err != nil {
-				// If graceful shutdown fails, force kill
-				if killErr := b.process.Process.Kill(); killErr != nil {
-					errs = append(errs, fmt.Sprintf("failed to stop watch process: %v", killErr))
-				}
-			}
-		}
-		b.isWatching = false
-		b.watchStatus.IsActive = false
-		b.process = nil
+	for _, err := range b.jobs.StopAll() {
+		errs = append(errs, err.Error())
 	}
+	b.watchStatus.IsActive = false
 
 	// Reset build status if running
 	if b.buildStatus.IsRunning {
@@ -361,21 +584,6 @@ func (b *Backend) StopProcess() error {
 	return nil
 }
 
-func (b *Backend) parseBuildOutput(stdout interface{}) {
-	if pipe, ok := stdout.(interface{ Read([]byte) (int, error) }); ok {
-		scanner := bufio.NewScanner(pipe)
-		for scanner.Scan() {
-			line := scanner.Text()
-
-			// Parse TUI_DATA lines
-			if strings.HasPrefix(line, "TUI_DATA:") {
-				jsonData := strings.TrimPrefix(line, "TUI_DATA:")
-				b.parseBuildTUIData(jsonData)
-			}
-		}
-	}
-}
-
 func (b *Backend) parseBuildTUIData(jsonData string) {
 	var tuiData TUIData
 	if err := json.Unmarshal([]byte(jsonData), &tuiData); err != nil {
@@ -388,7 +596,13 @@ func (b *Backend) parseBuildTUIData(jsonData string) {
 	// Update build status based on TUI data type
 	switch tuiData.Type {
 	case "progress":
-		if tuiData.Progress != nil {
+		if tuiData.Step != nil {
+			status := StepRunning
+			if tuiData.Status != nil {
+				status = *tuiData.Status
+			}
+			b.trackStepProgress(*tuiData.Step, status, tuiData.Progress)
+		} else if tuiData.Progress != nil {
 			b.buildStatus.Progress = *tuiData.Progress
 		}
 		if tuiData.Message != nil {
@@ -423,7 +637,13 @@ func (b *Backend) parseBuildTUIData(jsonData string) {
 			b.buildStatus.CurrentStep = "Completed"
 			b.buildStatus.Message = "Build completed successfully"
 		}
-	case "log":
+	case "report":
+		if tuiData.BuildID != nil {
+			b.buildStatus.ReportID = *tuiData.BuildID
+			go b.checkRegression(*tuiData.BuildID)
+		}
+	case "log", "error":
+		b.appendLog(tuiData, "build")
 		// Handle log messages for status updates
 		if tuiData.Message != nil {
 			message := *tuiData.Message
@@ -437,21 +657,185 @@ func (b *Backend) parseBuildTUIData(jsonData string) {
 	}
 }
 
-func (b *Backend) monitorBuildProcess(cmd *exec.Cmd) {
-	// Wait for process to complete
-	cmd.Wait()
+// trackStepProgress follows the adapter's per-step progress and turns it into
+// step-weighted overall progress. Caller must hold the mutex.
+func (b *Backend) trackStepProgress(phase, status string, progress *int) {
+	now := time.Now()
+	switch phase {
+	case "complete":
+		b.closeSteps("", StepCompleted, now)
+		b.buildStatus.Progress = 100
+		return
+	case "error":
+		b.closeSteps("", StepFailed, now)
+		return
+	}
+	name, ok := adapterSteps[phase]
+	if !ok {
+		return
+	}
+
+	b.recordStep(name, status, now)
+	if !b.buildStatus.Estimate.Has(name) {
+		// Steps without a weight leave the progress where it is
+		return
+	}
+	if name != b.buildStatus.StepName {
+		b.buildStatus.StepName = name
+		b.buildStatus.StepStartedAt = now
+		b.buildStatus.StepProgress = 0
+	}
+	if progress != nil {
+		b.buildStatus.StepProgress = *progress
+	}
+	b.buildStatus.Progress = b.buildStatus.OverallProgress(now)
+}
+
+// checkRegression compares a finished build with the rolling baseline and flags a slowdown
+func (b *Backend) checkRegression(buildID string) {
+	result, ok, err := checkBuildRegression(buildID)
+	if err != nil || !ok {
+		return
+	}
+
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	if b.buildStatus.ReportID != buildID {
+		return
+	}
+	b.buildStatus.Regression = &result
+	if result.Regressed {
+		b.logEvent("warning", "Build duration regression: "+result.Summary(), "build")
+	}
+}
 
+// finishBuild marks the build as done once its job exits
+func (b *Backend) finishBuild(job Job) {
 	b.mutex.Lock()
 	defer b.mutex.Unlock()
 
 	// Mark as completed when process ends
 	b.buildStatus.IsRunning = false
-	if b.buildStatus.Progress < 100 {
+	switch {
+	case job.State == JobStopped:
+		b.closeSteps("", StepStopped, job.FinishedAt)
+		b.buildStatus.Message = "Build stopped"
+	case job.State == JobFailed:
+		b.closeSteps("", StepFailed, job.FinishedAt)
+		b.buildStatus.CurrentStep = "Failed"
+		b.buildStatus.Message = fmt.Sprintf("Build failed (exit %d)", job.ExitCode)
+	case b.buildStatus.Progress < 100:
+		b.closeSteps("", StepCompleted, job.FinishedAt)
 		b.buildStatus.Progress = 100
 		b.buildStatus.Message = "Build completed"
 	}
 }
 
+// scriptJobID is the job ID of an npm script
+func scriptJobID(name string) string {
+	return "script:" + name
+}
+
+// StartScript runs an npm script from package.json as its own job, so different scripts can run side by side
+func (b *Backend) StartScript(name string) error {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	// npm is a .cmd shim on Windows and has to go through cmd
+	var cmd *exec.Cmd
+	if runtime.GOOS == "windows" {
+		cmd = exec.Command("cmd", "/c", "npm", "run", name)
+	} else {
+		cmd = exec.Command("npm", "run", name)
+	}
+	cmd.Dir = filepath.Join("..", "..")
+	cmd.Env = append(os.Environ(), "FORCE_COLOR=0")
+
+	id := scriptJobID(name)
+	err := b.jobs.Start(id, "script", "📜 "+name, cmd, JobHandlers{
+		OnExit: func(job Job) {
+			b.mutex.Lock()
+			defer b.mutex.Unlock()
+			b.scriptRuns = append(b.scriptRuns, scriptRunFromJob(name, job))
+			if len(b.scriptRuns) > maxScriptHistory {
+				b.scriptRuns = b.scriptRuns[len(b.scriptRuns)-maxScriptHistory:]
+			}
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to start script %q: %v", name, err)
+	}
+
+	b.lastScript = name
+	return nil
+}
+
+func scriptRunFromJob(name string, job Job) ScriptRun {
+	return ScriptRun{
+		Script:     name,
+		StartedAt:  job.StartedAt,
+		FinishedAt: job.FinishedAt,
+		IsRunning:  job.State == JobRunning,
+		ExitCode:   job.ExitCode,
+		Output:     job.Logs,
+	}
+}
+
+// StopScript interrupts the most recently started npm script
+func (b *Backend) StopScript() error {
+	b.mutex.RLock()
+	name := b.lastScript
+	b.mutex.RUnlock()
+
+	if name == "" || !b.jobs.IsRunning(scriptJobID(name)) {
+		return fmt.Errorf("no script running")
+	}
+	if err := b.jobs.Stop(scriptJobID(name)); err != nil {
+		return fmt.Errorf("failed to stop script: %v", err)
+	}
+	return nil
+}
+
+// GetScriptRun returns the current or most recent script run
+func (b *Backend) GetScriptRun() (ScriptRun, bool) {
+	b.mutex.RLock()
+	name := b.lastScript
+	b.mutex.RUnlock()
+
+	job, ok := b.jobs.Get(scriptJobID(name))
+	if name == "" || !ok {
+		return ScriptRun{}, false
+	}
+	return scriptRunFromJob(name, job), true
+}
+
+// GetScriptHistory returns finished script runs, oldest first
+func (b *Backend) GetScriptHistory() []ScriptRun {
+	b.mutex.RLock()
+	defer b.mutex.RUnlock()
+	history := make([]ScriptRun, len(b.scriptRuns))
+	copy(history, b.scriptRuns)
+	return history
+}
+
+// GetJobs returns every job the backend has started, oldest first
+func (b *Backend) GetJobs() []Job {
+	return b.jobs.List()
+}
+
+// StopJob interrupts a running job
+func (b *Backend) StopJob(id string) error {
+	if id == "watch" {
+		return b.StopWatch()
+	}
+	return b.jobs.Stop(id)
+}
+
+// DismissJob removes a finished job from the job list
+func (b *Backend) DismissJob(id string) error {
+	return b.jobs.Remove(id)
+}
+
 // GetWatchStatus retrieves the current watch status
 func (b *Backend) GetWatchStatus() WatchStatus {
 	b.mutex.RLock()
@@ -463,28 +847,55 @@ func (b *Backend) GetWatchStatus() WatchStatus {
 func (b *Backend) GetBuildStatus() BuildStatus {
 	b.mutex.RLock()
 	defer b.mutex.RUnlock()
-	return b.buildStatus
+	status := b.buildStatus
+	// Copy the timeline so running steps can be closed while it is rendered
+	status.Steps = append([]StepSpan(nil), status.Steps...)
+	return status
+}
+
+// GetLogs returns a copy of the buffered log entries, oldest first
+func (b *Backend) GetLogs() []LogEntry {
+	b.mutex.RLock()
+	defer b.mutex.RUnlock()
+	logs := make([]LogEntry, len(b.logs))
+	copy(logs, b.logs)
+	return logs
+}
+
+// GetDiagnostics returns a copy of the collected diagnostics
+func (b *Backend) GetDiagnostics() []Diagnostic {
+	b.mutex.RLock()
+	defer b.mutex.RUnlock()
+	diagnostics := make([]Diagnostic, len(b.diagnostics))
+	copy(diagnostics, b.diagnostics)
+	return diagnostics
+}
+
+// ClearDiagnostics forgets every collected diagnostic
+func (b *Backend) ClearDiagnostics() {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	b.diagnostics = nil
+}
+
+// GetChanges returns a copy of the change feed, oldest first
+func (b *Backend) GetChanges() []ChangeEntry {
+	b.mutex.RLock()
+	defer b.mutex.RUnlock()
+	changes := make([]ChangeEntry, len(b.changes))
+	copy(changes, b.changes)
+	return changes
 }
 
 // IsWatchActive checks if the watch process is active
 func (b *Backend) IsWatchActive() bool {
 	b.mutex.RLock()
 	defer b.mutex.RUnlock()
-	return b.isWatching && b.watchStatus.IsActive
+	return b.jobs.IsRunning("watch") && b.watchStatus.IsActive
 }
 
 // Cleanup method to be called when the TUI exits
 func (b *Backend) Cleanup() error {
+	b.stopOnce.Do(func() { close(b.stopSampler) })
 	return b.StopProcess()
 }
-
-// Placeholder methods for missing functionality
-func (b *Backend) GetAnalytics() error {
-	// TODO: Implement analytics retrieval
-	return nil
-}
-
-func (b *Backend) GetLogs() error {
-	// TODO: Implement log retrieval
-	return nil
-}
\ No newline at end of file
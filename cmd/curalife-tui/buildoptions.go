@@ -0,0 +1,394 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// AssetMode is whether the build optimizes images and fonts. Unset leaves it
+// to assets.enabled in the build config, like a plain `npm run build`.
+type AssetMode string
+
+const (
+	AssetsDefault AssetMode = ""
+	AssetsOn      AssetMode = "on"
+	AssetsOff     AssetMode = "off"
+)
+
+// UnmarshalJSON also accepts the booleans saved by earlier versions
+func (a *AssetMode) UnmarshalJSON(data []byte) error {
+	var on bool
+	if err := json.Unmarshal(data, &on); err == nil {
+		*a = AssetsOff
+		if on {
+			*a = AssetsOn
+		}
+		return nil
+	}
+	return json.Unmarshal(data, (*string)(a))
+}
+
+// next cycles through config default, on and off
+func (a AssetMode) next() AssetMode {
+	switch a {
+	case AssetsDefault:
+		return AssetsOn
+	case AssetsOn:
+		return AssetsOff
+	}
+	return AssetsDefault
+}
+
+// BuildOptions selects how the build adapter runs the build
+type BuildOptions struct {
+	Profile      string    `json:"profile"`
+	Report       bool      `json:"report"`
+	Assets       AssetMode `json:"assets"`
+	Cache        bool      `json:"cache"`
+	AutoOptimize bool      `json:"autoOptimize"`
+}
+
+// BuildProfile is a named, reusable set of build options
+type BuildProfile struct {
+	Name    string       `json:"name"`
+	Options BuildOptions `json:"options"`
+	BuiltIn bool         `json:"-"`
+}
+
+// DefaultBuildOptions matches a plain `npm run build`
+func DefaultBuildOptions() BuildOptions {
+	return BuildOptions{Profile: "default", Cache: true}
+}
+
+// adapterArgs converts the options into build-adapter.js flags
+func (o BuildOptions) adapterArgs() []string {
+	var args []string
+	if o.Report {
+		args = append(args, "--report")
+	}
+	switch o.Assets {
+	case AssetsOn:
+		args = append(args, "--assets")
+	case AssetsOff:
+		args = append(args, "--no-assets")
+	}
+	// The auto-optimizing workflow has no cache switch
+	if !o.Cache && !o.AutoOptimize {
+		args = append(args, "--no-cache")
+	}
+	if o.AutoOptimize {
+		args = append(args, "--auto-optimize")
+	}
+	if o.Profile != "" {
+		args = append(args, "--profile", o.Profile)
+	}
+	return args
+}
+
+// Summary describes the options in one line
+func (o BuildOptions) Summary() string {
+	var parts []string
+	if o.Report {
+		parts = append(parts, "report")
+	}
+	switch o.Assets {
+	case AssetsOn:
+		parts = append(parts, "assets")
+	case AssetsOff:
+		parts = append(parts, "no assets")
+	}
+	if !o.Cache && !o.AutoOptimize {
+		parts = append(parts, "no cache")
+	}
+	if o.AutoOptimize {
+		parts = append(parts, "auto-optimize")
+	}
+	if len(parts) == 0 {
+		return "standard"
+	}
+	return strings.Join(parts, ", ")
+}
+
+// builtInProfiles mirror the build scripts in package.json
+func builtInProfiles() []BuildProfile {
+	report := DefaultBuildOptions()
+	report.Profile, report.Report = "report", true
+	noAssets := DefaultBuildOptions()
+	noAssets.Profile, noAssets.Assets = "no-assets", AssetsOff
+	optimized := DefaultBuildOptions()
+	optimized.Profile, optimized.AutoOptimize = "optimized", true
+	full := optimized
+	full.Profile, full.Report = "full", true
+
+	var profiles []BuildProfile
+	for _, options := range []BuildOptions{DefaultBuildOptions(), report, noAssets, optimized, full} {
+		profiles = append(profiles, BuildProfile{Name: options.Profile, Options: options, BuiltIn: true})
+	}
+	return profiles
+}
+
+// configDir returns the directory holding the TUI's user configuration
+func configDir() string {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		dir = os.TempDir()
+	}
+	return filepath.Join(dir, "curalife-tui")
+}
+
+// loadConfigFile decodes a JSON file from the config directory; a missing file is not an error
+func loadConfigFile(name string, v interface{}) error {
+	data, err := os.ReadFile(filepath.Join(configDir(), name))
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
+
+// saveConfigFile writes a JSON file into the config directory
+func saveConfigFile(name string, v interface{}) error {
+	if err := os.MkdirAll(configDir(), 0o755); err != nil {
+		return fmt.Errorf("failed to create config directory: %v", err)
+	}
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(configDir(), name), data, 0o644)
+}
+
+const profilesFile = "profiles.json"
+
+// loadProfiles returns the built-in profiles followed by the saved ones
+func loadProfiles() []BuildProfile {
+	var saved []BuildProfile
+	loadConfigFile(profilesFile, &saved)
+	return append(builtInProfiles(), saved...)
+}
+
+// saveProfile stores the options under name, replacing a saved profile with the same name
+func saveProfile(name string, options BuildOptions) ([]BuildProfile, error) {
+	for _, profile := range builtInProfiles() {
+		if profile.Name == name {
+			return nil, fmt.Errorf("%q is a built-in profile", name)
+		}
+	}
+
+	var saved []BuildProfile
+	if err := loadConfigFile(profilesFile, &saved); err != nil {
+		return nil, fmt.Errorf("failed to read profiles: %v", err)
+	}
+
+	options.Profile = name
+	replaced := false
+	for i := range saved {
+		if saved[i].Name == name {
+			saved[i].Options = options
+			replaced = true
+		}
+	}
+	if !replaced {
+		saved = append(saved, BuildProfile{Name: name, Options: options})
+	}
+
+	if err := saveConfigFile(profilesFile, saved); err != nil {
+		return nil, fmt.Errorf("failed to save profiles: %v", err)
+	}
+	return loadProfiles(), nil
+}
+
+// Rows of the build options form
+const (
+	optionProfile = iota
+	optionReport
+	optionAssets
+	optionCache
+	optionAutoOptimize
+	optionStart
+	optionSave
+	optionCount
+)
+
+// openBuildOptions shows the pre-build form, starting from the given options
+func (m Model) openBuildOptions(options BuildOptions) Model {
+	if options == (BuildOptions{}) {
+		options = DefaultBuildOptions()
+	}
+	m.state = StateBuildOptions
+	m.profiles = loadProfiles()
+	m.buildOptions = options
+	m.optionsCursor = optionStart
+	m.profileIndex = 0
+	for i, profile := range m.profiles {
+		if profile.Name == options.Profile {
+			m.profileIndex = i
+		}
+	}
+	return m
+}
+
+// startBuild switches to the build screen and starts the build in the background
+func (m Model) startBuild(options BuildOptions) Model {
+	m.state = StateBuild
+	m.feedCursor = 0
+	go func() {
+		m.backend.StartBuild(options)
+	}()
+	return m
+}
+
+func (m Model) handleBuildOptionsKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	// Typing a profile name captures every key until enter or esc
+	if m.namingProfile {
+		switch msg.Type {
+		case tea.KeyEnter:
+			m.namingProfile = false
+			name := strings.TrimSpace(m.profileName)
+			if name == "" {
+				return m, nil
+			}
+			profiles, err := saveProfile(name, m.buildOptions)
+			if err != nil {
+				m.statusMessage = fmt.Sprintf("❌ %v", err)
+				return m, nil
+			}
+			m.profiles = profiles
+			m.buildOptions.Profile = name
+			m.profileIndex = len(profiles) - 1
+			for i, profile := range profiles {
+				if profile.Name == name {
+					m.profileIndex = i
+				}
+			}
+			m.statusMessage = fmt.Sprintf("💾 Saved profile %q", name)
+		case tea.KeyEsc:
+			m.namingProfile = false
+		case tea.KeyBackspace:
+			if len(m.profileName) > 0 {
+				runes := []rune(m.profileName)
+				m.profileName = string(runes[:len(runes)-1])
+			}
+		case tea.KeyRunes:
+			m.profileName += string(msg.Runes)
+		}
+		return m, nil
+	}
+
+	switch msg.String() {
+	case "ctrl+c", "q":
+		return m, tea.Quit
+	case "esc":
+		m.state = StateMenu
+	case "up", "k":
+		if m.optionsCursor > 0 {
+			m.optionsCursor--
+		}
+	case "down", "j", "tab":
+		if m.optionsCursor < optionCount-1 {
+			m.optionsCursor++
+		}
+	case "left", "h", "right", "l":
+		if m.optionsCursor == optionProfile && len(m.profiles) > 0 {
+			if msg.String() == "left" || msg.String() == "h" {
+				m.profileIndex = (m.profileIndex + len(m.profiles) - 1) % len(m.profiles)
+			} else {
+				m.profileIndex = (m.profileIndex + 1) % len(m.profiles)
+			}
+			m.buildOptions = m.profiles[m.profileIndex].Options
+		}
+	case "enter", " ":
+		if m.optionsCursor == optionCache && m.buildOptions.AutoOptimize {
+			m.statusMessage = "The auto-optimizing workflow always uses the cache"
+			return m, nil
+		}
+		if m.optionsCursor >= optionReport && m.optionsCursor <= optionAutoOptimize {
+			// Any change turns the selection into a custom profile
+			m.buildOptions.Profile = ""
+		}
+		switch m.optionsCursor {
+		case optionReport:
+			m.buildOptions.Report = !m.buildOptions.Report
+		case optionAssets:
+			m.buildOptions.Assets = m.buildOptions.Assets.next()
+		case optionCache:
+			m.buildOptions.Cache = !m.buildOptions.Cache
+		case optionAutoOptimize:
+			m.buildOptions.AutoOptimize = !m.buildOptions.AutoOptimize
+		case optionStart:
+			return m.startBuild(m.buildOptions), nil
+		case optionSave:
+			m.namingProfile = true
+			m.profileName = ""
+		}
+	}
+	return m, nil
+}
+
+func (m Model) renderBuildOptions() string {
+	s := "\n"
+	s += titleStyle.Render("⚙️  BUILD OPTIONS") + "\n\n"
+
+	checkbox := func(on bool) string {
+		if on {
+			return "[x]"
+		}
+		return "[ ]"
+	}
+	profile := m.buildOptions.Profile
+	if profile == "" {
+		profile = "custom"
+	}
+
+	assets := map[AssetMode]string{AssetsDefault: "[~] Asset optimization (images, fonts): build config default",
+		AssetsOn: "[x] Asset optimization (images, fonts)", AssetsOff: "[ ] Asset optimization (images, fonts)"}
+	cache := fmt.Sprintf("%s Build cache", checkbox(m.buildOptions.Cache))
+	if m.buildOptions.AutoOptimize {
+		cache = "[x] Build cache (always on with auto-optimize)"
+	}
+
+	rows := []string{
+		fmt.Sprintf("Profile:        ◀ %s ▶", profile),
+		fmt.Sprintf("%s Report (bundle analysis)", checkbox(m.buildOptions.Report)),
+		assets[m.buildOptions.Assets],
+		cache,
+		fmt.Sprintf("%s Auto-optimize (workflow integration)", checkbox(m.buildOptions.AutoOptimize)),
+		"▶ Start build",
+		"💾 Save as profile...",
+	}
+	for i, row := range rows {
+		if i == optionStart {
+			s += "\n"
+		}
+		switch {
+		case i == m.optionsCursor:
+			s += selectedStyle.Render("> "+row) + "\n"
+		case i == optionCache && m.buildOptions.AutoOptimize:
+			s += detailStyle.Render("  "+row) + "\n"
+		default:
+			s += normalStyle.Render("  "+row) + "\n"
+		}
+	}
+
+	s += "\n" + detailStyle.Render(fmt.Sprintf("Options: %s", m.buildOptions.Summary())) + "\n"
+	if m.buildOptions.AutoOptimize && m.buildOptions.Report {
+		s += detailStyle.Render("Note: auto-optimized builds report through verbose workflow output") + "\n"
+	}
+
+	if m.namingProfile {
+		s += "\n" + infoStyle.Render(fmt.Sprintf("Profile name: %s█", m.profileName)) + "\n"
+	}
+	if m.statusMessage != "" {
+		s += "\n" + infoStyle.Render(m.statusMessage) + "\n"
+	}
+
+	s += "\n" + helpStyle.Render("↑/↓: navigate • ←/→: switch profile • space: toggle • enter: select • esc: return to menu") + "\n"
+	return s
+}
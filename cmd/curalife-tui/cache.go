@@ -0,0 +1,467 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// staleCacheAge matches the one-week cutoff of BuildCache.cleanupDiskCache
+const staleCacheAge = 7 * 24 * time.Hour
+
+// cacheDir is build-scripts/cache, as configured in unified-config.js
+func cacheDir() string {
+	return filepath.Join(projectRoot(), "build-scripts", "cache")
+}
+
+func buildCacheFile() string {
+	return filepath.Join(cacheDir(), ".build-cache.json")
+}
+
+func dependencyCacheFile() string {
+	return filepath.Join(cacheDir(), ".dependency-cache.json")
+}
+
+func performanceLogFile() string {
+	return filepath.Join(cacheDir(), ".build-performance.json")
+}
+
+// CacheEntryState says whether a cached hash still matches its source file
+type CacheEntryState string
+
+const (
+	CacheValid    CacheEntryState = "valid"
+	CacheStale    CacheEntryState = "stale"    // not refreshed for a week
+	CacheModified CacheEntryState = "modified" // hash mismatch
+	CacheMissing  CacheEntryState = "missing"  // source file no longer exists
+)
+
+// Invalid reports whether the next build would ignore the entry anyway
+func (s CacheEntryState) Invalid() bool {
+	return s == CacheModified || s == CacheMissing
+}
+
+// CacheEntry is one file in .build-cache.json
+type CacheEntry struct {
+	Path         string          `json:"-"`
+	Hash         string          `json:"hash"`
+	LastModified int64           `json:"lastModified"`
+	BuildCount   int             `json:"buildCount"`
+	State        CacheEntryState `json:"-"`
+}
+
+// CacheFile is one file in build-scripts/cache
+type CacheFile struct {
+	Name string
+	Size int64
+}
+
+// CacheInspection is what the Cache screen shows
+type CacheInspection struct {
+	Data    CacheData
+	Entries []CacheEntry // invalid entries first, then by path
+	Files   []CacheFile
+	Stale   int
+	// From .build-performance.json
+	RecordedBuilds   int
+	AverageBuildTime time.Duration
+}
+
+// performanceLog is the part of .build-performance.json the inspector reads
+type performanceLog struct {
+	AverageBuildTime float64 `json:"averageBuildTime"`
+	BuildHistory     []struct {
+		Timestamp      int64   `json:"timestamp"`
+		Duration       float64 `json:"duration"`
+		FilesProcessed int     `json:"filesProcessed"`
+		CacheHits      int     `json:"cacheHits"`
+	} `json:"buildHistory"`
+}
+
+// readBuildCache returns .build-cache.json as raw top-level fields, so a
+// pruned cache can be written back without dropping fields it doesn't know
+func readBuildCache() (map[string]json.RawMessage, map[string]CacheEntry, error) {
+	raw := make(map[string]json.RawMessage)
+	files := make(map[string]CacheEntry)
+	data, err := os.ReadFile(buildCacheFile())
+	if os.IsNotExist(err) {
+		return raw, files, nil
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read build cache: %v", err)
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, nil, fmt.Errorf("failed to parse build cache: %v", err)
+	}
+	if encoded, ok := raw["files"]; ok {
+		if err := json.Unmarshal(encoded, &files); err != nil {
+			return nil, nil, fmt.Errorf("failed to parse build cache entries: %v", err)
+		}
+	}
+	return raw, files, nil
+}
+
+// nodeMtime is the mtime as Node's fs.Stats reports it: a float64 count of
+// milliseconds, rounded to a whole millisecond for the Date
+func nodeMtime(mtime time.Time) time.Time {
+	ms := float64(mtime.Unix())*1e3 + float64(mtime.Nanosecond())/1e6
+	return time.UnixMilli(int64(math.Floor(ms + 0.5)))
+}
+
+// sourceHash reproduces BuildCache.getFileHash: SHA-256 over the mtime as an
+// ISO timestamp, the size and the content
+func sourceHash(path string) (string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+	info, err := file.Stat()
+	if err != nil {
+		return "", err
+	}
+
+	hash := sha256.New()
+	io.WriteString(hash, nodeMtime(info.ModTime()).UTC().Format("2006-01-02T15:04:05.000Z"))
+	io.WriteString(hash, strconv.FormatInt(info.Size(), 10))
+	if _, err := io.Copy(hash, file); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// cacheEntryState checks an entry against its source file as of now
+func cacheEntryState(entry CacheEntry, now time.Time) CacheEntryState {
+	// The build engine keys entries by absolute path; older caches used relative ones
+	path := entry.Path
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(projectRoot(), path)
+	}
+	hash, err := sourceHash(path)
+	switch {
+	case os.IsNotExist(err):
+		return CacheMissing
+	case err != nil || hash != entry.Hash:
+		return CacheModified
+	case now.Sub(time.UnixMilli(entry.LastModified)) > staleCacheAge:
+		return CacheStale
+	}
+	return CacheValid
+}
+
+// inspectCache reads build-scripts/cache and checks every entry against src
+func inspectCache() (CacheInspection, error) {
+	var inspection CacheInspection
+	now := time.Now()
+
+	dirEntries, err := os.ReadDir(cacheDir())
+	if err != nil && !os.IsNotExist(err) {
+		return inspection, fmt.Errorf("failed to read %s: %v", cacheDir(), err)
+	}
+	for _, dirEntry := range dirEntries {
+		info, err := dirEntry.Info()
+		if err != nil || info.IsDir() {
+			continue
+		}
+		inspection.Files = append(inspection.Files, CacheFile{Name: dirEntry.Name(), Size: info.Size()})
+		inspection.Data.CacheSize += info.Size()
+	}
+
+	raw, files, err := readBuildCache()
+	if err != nil {
+		return inspection, err
+	}
+	for path, entry := range files {
+		entry.Path = path
+		entry.State = cacheEntryState(entry, now)
+		switch {
+		case entry.State.Invalid():
+			inspection.Data.InvalidEntries++
+		case entry.State == CacheStale:
+			inspection.Stale++
+		}
+		inspection.Entries = append(inspection.Entries, entry)
+	}
+	sort.Slice(inspection.Entries, func(i, j int) bool {
+		a, b := inspection.Entries[i], inspection.Entries[j]
+		if a.State != b.State {
+			return cacheStateOrder(a.State) < cacheStateOrder(b.State)
+		}
+		return a.Path < b.Path
+	})
+	inspection.Data.TotalEntries = len(inspection.Entries)
+
+	var lastCleanup int64
+	if encoded, ok := raw["lastCleanup"]; ok {
+		json.Unmarshal(encoded, &lastCleanup)
+	}
+	if lastCleanup > 0 {
+		inspection.Data.LastCleanup = time.UnixMilli(lastCleanup)
+	}
+
+	if data, err := os.ReadFile(performanceLogFile()); err == nil {
+		var log performanceLog
+		if err := json.Unmarshal(data, &log); err == nil {
+			inspection.RecordedBuilds = len(log.BuildHistory)
+			inspection.AverageBuildTime = time.Duration(log.AverageBuildTime * float64(time.Millisecond))
+			// Hit rate of the most recent build
+			if n := len(log.BuildHistory); n > 0 {
+				last := log.BuildHistory[n-1]
+				if lookups := last.CacheHits + last.FilesProcessed; lookups > 0 {
+					inspection.Data.HitRate = float64(last.CacheHits) / float64(lookups)
+					inspection.Data.MissRate = 1 - inspection.Data.HitRate
+				}
+			}
+		}
+	}
+	return inspection, nil
+}
+
+func cacheStateOrder(state CacheEntryState) int {
+	switch state {
+	case CacheMissing:
+		return 0
+	case CacheModified:
+		return 1
+	case CacheStale:
+		return 2
+	}
+	return 3
+}
+
+// cacheInUse reports the running job that reads and rewrites the build cache, if any
+func (b *Backend) cacheInUse() (Job, bool) {
+	for _, job := range b.jobs.List() {
+		if job.State == JobRunning && (job.Kind == "build" || job.Kind == "watch") {
+			return job, true
+		}
+	}
+	return Job{}, false
+}
+
+// PruneCache drops invalid and week-old entries from .build-cache.json, like
+// the build engine's daily cleanup, and returns how many were removed
+func (b *Backend) PruneCache() (int, error) {
+	if job, ok := b.cacheInUse(); ok {
+		return 0, fmt.Errorf("%s is running and would overwrite the cache", job.Title)
+	}
+	raw, files, err := readBuildCache()
+	if err != nil {
+		return 0, err
+	}
+	if len(files) == 0 {
+		return 0, nil
+	}
+
+	now := time.Now()
+	removed := 0
+	for path, entry := range files {
+		entry.Path = path
+		if state := cacheEntryState(entry, now); state != CacheValid {
+			delete(files, path)
+			removed++
+		}
+	}
+
+	encoded, err := json.Marshal(files)
+	if err != nil {
+		return 0, fmt.Errorf("failed to encode build cache: %v", err)
+	}
+	raw["files"] = encoded
+	raw["lastCleanup"] = json.RawMessage(strconv.FormatInt(now.UnixMilli(), 10))
+	data, err := json.MarshalIndent(raw, "", "  ")
+	if err != nil {
+		return 0, fmt.Errorf("failed to encode build cache: %v", err)
+	}
+	if err := os.WriteFile(buildCacheFile(), data, 0644); err != nil {
+		return 0, fmt.Errorf("failed to write build cache: %v", err)
+	}
+	return removed, nil
+}
+
+// WipeCache deletes the build and dependency caches so the next build copies
+// every file. The performance log is kept since it holds build history.
+func (b *Backend) WipeCache() error {
+	if job, ok := b.cacheInUse(); ok {
+		return fmt.Errorf("%s is running and would overwrite the cache", job.Title)
+	}
+	for _, path := range []string{buildCacheFile(), dependencyCacheFile()} {
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to remove %s: %v", filepath.Base(path), err)
+		}
+	}
+	return nil
+}
+
+// cacheInspectedMsg carries a finished cache inspection, with the status of
+// the action that preceded it
+type cacheInspectedMsg struct {
+	Inspection CacheInspection
+	Err        error
+	Status     string
+}
+
+// inspectCacheCmd runs action, if any, and then inspects the cache off the UI
+// goroutine, since checking the entries hashes every cached source file
+func inspectCacheCmd(action func() (string, error)) tea.Cmd {
+	return func() tea.Msg {
+		status := ""
+		if action != nil {
+			var err error
+			if status, err = action(); err != nil {
+				return URLActionMsg{Err: err}
+			}
+		}
+		inspection, err := inspectCache()
+		return cacheInspectedMsg{Inspection: inspection, Err: err, Status: status}
+	}
+}
+
+// openCache shows the Cache screen and starts inspecting build-scripts/cache
+func (m Model) openCache() (Model, tea.Cmd) {
+	m.state = StateCache
+	m.cacheScroll = 0
+	m.cacheWipeArmed = false
+	m.cacheLoading = true
+	return m, inspectCacheCmd(nil)
+}
+
+// showCache takes a finished inspection onto the Cache screen
+func (m Model) showCache(msg cacheInspectedMsg) Model {
+	m.cache = msg.Inspection
+	m.cacheLoading = false
+	m.cacheScroll = min(m.cacheScroll, max(len(m.cache.Entries)-m.cacheEntryLines(), 0))
+	switch {
+	case m.state != StateCache:
+		// Left the screen before the inspection finished
+	case msg.Err != nil:
+		m.statusMessage = fmt.Sprintf("❌ %v", msg.Err)
+	case msg.Status != "":
+		m.statusMessage = msg.Status
+	}
+	return m
+}
+
+func (m Model) handleCacheKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	// Wiping needs a second W; any other key cancels it
+	armed := m.cacheWipeArmed
+	m.cacheWipeArmed = false
+
+	switch msg.String() {
+	case "ctrl+c", "q":
+		m.backend.Cleanup()
+		return m, tea.Quit
+	case "esc":
+		m.state = StateMenu
+	case "r":
+		m.statusMessage = ""
+		return m.openCache()
+	case "p":
+		m.statusMessage = "🧹 Pruning cache entries..."
+		return m, inspectCacheCmd(func() (string, error) {
+			removed, err := m.backend.PruneCache()
+			return fmt.Sprintf("🧹 Pruned %d cache entries", removed), err
+		})
+	case "w":
+		if !armed {
+			m.cacheWipeArmed = true
+			m.statusMessage = "⚠️  Press W again to wipe the cache; the next build copies every file"
+			return m, nil
+		}
+		m.statusMessage = ""
+		return m, inspectCacheCmd(func() (string, error) {
+			return "🗑️  Cache wiped", m.backend.WipeCache()
+		})
+	case "up", "k":
+		m.cacheScroll = max(m.cacheScroll-1, 0)
+	case "down", "j":
+		m.cacheScroll = min(m.cacheScroll+1, max(len(m.cache.Entries)-m.cacheEntryLines(), 0))
+	}
+	return m, nil
+}
+
+// cacheEntryLines is how many entries fit below the summary on the Cache screen
+func (m Model) cacheEntryLines() int {
+	return max(m.visibleLines()-18, 5)
+}
+
+func (m Model) renderCache() string {
+	s := "\n"
+	s += titleStyle.Render("🗄️  BUILD CACHE") + "\n\n"
+
+	if m.cacheLoading {
+		s += detailStyle.Render("Checking cache entries against src...") + "\n"
+		s += "\n" + helpStyle.Render("esc: return to menu") + "\n"
+		return s
+	}
+
+	cache := m.cache
+	data := cache.Data
+	s += statsStyle.Render("📦 build-scripts/cache:") + "\n"
+	if len(cache.Files) == 0 {
+		s += detailStyle.Render("  Empty — the next build creates it") + "\n"
+	}
+	for _, file := range cache.Files {
+		s += detailStyle.Render(fmt.Sprintf("  %-26s %10s", file.Name, formatBytes(file.Size))) + "\n"
+	}
+	s += detailStyle.Render(fmt.Sprintf("  %-26s %10s", "Total", formatBytes(data.CacheSize))) + "\n\n"
+
+	s += statsStyle.Render("🔎 Entries:") + "\n"
+	s += detailStyle.Render(fmt.Sprintf("  Total:          %d", data.TotalEntries)) + "\n"
+	invalid := fmt.Sprintf("  Invalid:        %d (source changed or removed)", data.InvalidEntries)
+	if data.InvalidEntries > 0 {
+		s += errorStyle.Render(invalid) + "\n"
+	} else {
+		s += detailStyle.Render(invalid) + "\n"
+	}
+	s += detailStyle.Render(fmt.Sprintf("  Stale:          %d (not refreshed for a week)", cache.Stale)) + "\n"
+	lastCleanup := "never"
+	if !data.LastCleanup.IsZero() {
+		lastCleanup = data.LastCleanup.Format("2006-01-02 15:04")
+	}
+	s += detailStyle.Render(fmt.Sprintf("  Last cleanup:   %s", lastCleanup)) + "\n"
+	if cache.RecordedBuilds > 0 {
+		s += detailStyle.Render(fmt.Sprintf("  Last hit rate:  %.0f%%", data.HitRate*100)) + "\n"
+		s += detailStyle.Render(fmt.Sprintf("  Perf. log:      %d builds, %s average", cache.RecordedBuilds, formatMillis(cache.AverageBuildTime.Milliseconds()))) + "\n"
+	}
+	s += "\n"
+
+	if len(cache.Entries) > 0 {
+		root, _ := filepath.Abs(projectRoot())
+		visible := m.cacheEntryLines()
+		scroll := min(m.cacheScroll, max(len(cache.Entries)-visible, 0))
+		end := min(scroll+visible, len(cache.Entries))
+		for _, entry := range cache.Entries[scroll:end] {
+			path := entry.Path
+			if rel, err := filepath.Rel(root, path); err == nil && filepath.IsAbs(path) {
+				path = rel
+			}
+			line := fmt.Sprintf("  %-9s %5d builds  %s", entry.State, entry.BuildCount, truncate(path, 80))
+			if entry.State.Invalid() {
+				s += errorStyle.Render(line) + "\n"
+			} else {
+				s += detailStyle.Render(line) + "\n"
+			}
+		}
+		if len(cache.Entries) > visible {
+			s += detailStyle.Render(fmt.Sprintf("entries %d-%d of %d", scroll+1, end, len(cache.Entries))) + "\n"
+		}
+	}
+
+	if m.statusMessage != "" {
+		s += "\n" + infoStyle.Render(m.statusMessage) + "\n"
+	}
+
+	s += "\n" + helpStyle.Render("↑/↓: scroll • p: prune invalid and stale entries • w: wipe cache • r: refresh • esc: return to menu") + "\n"
+	return s
+}
@@ -0,0 +1,59 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestSourceHash(t *testing.T) {
+	// Expected hashes are what BuildCache.getFileHash in optimized-utils.js
+	// returns for the same file under Node 20
+	const content = "{% render 'price', product: product %}\n"
+	tests := []struct {
+		name  string
+		mtime time.Time
+		want  string
+	}{
+		{
+			name:  "sub-millisecond mtime rounds up",
+			mtime: time.Date(2024, 5, 1, 12, 0, 0, 123789000, time.UTC),
+			want:  "78040141653881ce42496192ce8e2712a4530db6a6341bf9f3dd014f409b89c7",
+		},
+		{
+			// Node's float64 milliseconds lose the last nanosecond, so this rounds up too
+			name:  "just under half a millisecond",
+			mtime: time.Date(2024, 5, 1, 12, 0, 0, 123499999, time.UTC),
+			want:  "78040141653881ce42496192ce8e2712a4530db6a6341bf9f3dd014f409b89c7",
+		},
+		{
+			name:  "rounds into the next second",
+			mtime: time.Date(2024, 5, 1, 12, 0, 0, 999600000, time.UTC),
+			want:  "04dfdf7c29b7df4b0168edecb0b50a146ccdc4ee448f352b4e60928b71a03876",
+		},
+	}
+
+	path := filepath.Join(t.TempDir(), "product-card.liquid")
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := os.Chtimes(path, tt.mtime, tt.mtime); err != nil {
+				t.Fatal(err)
+			}
+			got, err := sourceHash(path)
+			if err != nil {
+				t.Fatalf("sourceHash: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("sourceHash = %s, want %s", got, tt.want)
+			}
+		})
+	}
+
+	if _, err := sourceHash(filepath.Join(t.TempDir(), "missing.liquid")); !os.IsNotExist(err) {
+		t.Errorf("missing file: got %v, want a not-exist error", err)
+	}
+}
@@ -0,0 +1,368 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// compareThreshold is the relative change above which a delta is highlighted
+const compareThreshold = 0.10
+
+// Units of a compared metric, which also decide formatting and the noise floor
+const (
+	unitMillis  = "ms"
+	unitBytes   = "bytes"
+	unitCount   = "count"
+	unitPercent = "%"
+	unitScore   = "score" // Lighthouse category score, 0-100
+	unitRatio   = "ratio" // unitless, such as CLS
+)
+
+// MetricDelta is one metric measured in two builds
+type MetricDelta struct {
+	Label  string
+	Unit   string
+	Before float64
+	After  float64
+	// HigherIsBetter flips the direction of a regression, e.g. for cache hit rate
+	HigherIsBetter bool
+}
+
+// Change is the relative change from Before to After
+func (d MetricDelta) Change() float64 {
+	if d.Before == 0 {
+		if d.After == 0 {
+			return 0
+		}
+		return 1
+	}
+	return (d.After - d.Before) / d.Before
+}
+
+// Significant reports whether the change passes the threshold and is not just
+// noise, such as a 5ms step taking 8ms
+func (d MetricDelta) Significant() bool {
+	// Scores are already relative, so a few points matter regardless of the level
+	if d.Unit == unitScore {
+		return math.Abs(d.After-d.Before) >= 5
+	}
+	floor := map[string]float64{unitMillis: 100, unitBytes: 1024, unitCount: 1, unitPercent: 1, unitScore: 1, unitRatio: 0.01}[d.Unit]
+	return math.Abs(d.Change()) > compareThreshold && math.Abs(d.After-d.Before) >= floor
+}
+
+// Regressed reports whether the metric got worse
+func (d MetricDelta) Regressed() bool {
+	if d.HigherIsBetter {
+		return d.After < d.Before
+	}
+	return d.After > d.Before
+}
+
+// format renders a value of the delta's unit
+func (d MetricDelta) format(value float64) string {
+	switch d.Unit {
+	case unitMillis:
+		return formatMillis(int64(value))
+	case unitBytes:
+		return formatBytes(int64(value))
+	case unitPercent:
+		return fmt.Sprintf("%.0f%%", value)
+	case unitRatio:
+		return fmt.Sprintf("%.3f", value)
+	default:
+		return fmt.Sprintf("%.0f", value)
+	}
+}
+
+// DeltaString renders the absolute and relative change, e.g. "+1.2s (+40%)"
+func (d MetricDelta) DeltaString() string {
+	diff := d.After - d.Before
+	if diff == 0 {
+		return "—"
+	}
+	sign := "+"
+	if diff < 0 {
+		sign = "-"
+	}
+	abs := d.format(math.Abs(diff))
+	if d.Unit == unitPercent || d.Unit == unitScore {
+		return fmt.Sprintf("%s%.0f pts", sign, math.Abs(diff))
+	}
+	if d.Before == 0 {
+		return sign + abs
+	}
+	return fmt.Sprintf("%s%s (%+.0f%%)", sign, abs, d.Change()*100)
+}
+
+// ReportComparison is the diff of two build reports
+type ReportComparison struct {
+	Before  BuildReport
+	After   BuildReport
+	Overall []MetricDelta
+	Steps   []MetricDelta
+	Bundles []MetricDelta
+	Files   []MetricDelta
+}
+
+// compareReports diffs two builds; before should be the older one
+func compareReports(before, after BuildReport) ReportComparison {
+	b, a := before.Metrics, after.Metrics
+	comparison := ReportComparison{Before: before, After: after}
+
+	comparison.Overall = []MetricDelta{
+		{Label: "Duration", Unit: unitMillis, Before: float64(b.BuildTime), After: float64(a.BuildTime)},
+		{Label: "Files processed", Unit: unitCount, Before: float64(b.FilesProcessed), After: float64(a.FilesProcessed)},
+		{Label: "Cache hits", Unit: unitCount, Before: float64(b.CacheHits), After: float64(a.CacheHits), HigherIsBetter: true},
+		{Label: "Cache hit rate", Unit: unitPercent, Before: float64(b.CacheEfficiency), After: float64(a.CacheEfficiency), HigherIsBetter: true},
+		{Label: "Asset count", Unit: unitCount, Before: float64(b.Assets.Count), After: float64(a.Assets.Count)},
+		{Label: "Asset size", Unit: unitBytes, Before: float64(b.Assets.TotalSize), After: float64(a.Assets.TotalSize)},
+		{Label: "Bundle size", Unit: unitBytes, Before: float64(before.Analysis.Bundles.TotalSize), After: float64(after.Analysis.Bundles.TotalSize)},
+		{Label: "Errors", Unit: unitCount, Before: float64(len(b.Errors)), After: float64(len(a.Errors))},
+		{Label: "Warnings", Unit: unitCount, Before: float64(len(b.Warnings)), After: float64(len(a.Warnings))},
+	}
+
+	// Steps in the order the newer build ran them, then any it no longer has
+	beforeSteps, afterSteps := stepDurations(b.Steps), stepDurations(a.Steps)
+	for _, name := range unionNames(stepNames(a.Steps), stepNames(b.Steps)) {
+		comparison.Steps = append(comparison.Steps, MetricDelta{
+			Label: name, Unit: unitMillis, Before: beforeSteps[name], After: afterSteps[name],
+		})
+	}
+
+	// Only bundles that changed, biggest change first
+	var bundleNames []string
+	for name := range b.BundleSizes {
+		bundleNames = append(bundleNames, name)
+	}
+	for name := range a.BundleSizes {
+		bundleNames = append(bundleNames, name)
+	}
+	sort.Strings(bundleNames)
+	for _, name := range unionNames(bundleNames) {
+		delta := MetricDelta{
+			Label: name, Unit: unitBytes,
+			Before: float64(b.BundleSizes[name].Size), After: float64(a.BundleSizes[name].Size),
+		}
+		if delta.Before != delta.After {
+			comparison.Bundles = append(comparison.Bundles, delta)
+		}
+	}
+	sortByAbsoluteChange(comparison.Bundles)
+
+	// Files whose processing time grew the most
+	beforeFiles, afterFiles := fileTimes(b.FileDetails), fileTimes(a.FileDetails)
+	for path, after := range afterFiles {
+		if delta := (MetricDelta{Label: path, Unit: unitMillis, Before: beforeFiles[path], After: after}); delta.After > delta.Before {
+			comparison.Files = append(comparison.Files, delta)
+		}
+	}
+	sortByAbsoluteChange(comparison.Files)
+	if len(comparison.Files) > 10 {
+		comparison.Files = comparison.Files[:10]
+	}
+
+	return comparison
+}
+
+func stepDurations(steps []StepTiming) map[string]float64 {
+	durations := make(map[string]float64)
+	for _, step := range steps {
+		durations[step.Name] += float64(step.Duration)
+	}
+	return durations
+}
+
+func stepNames(steps []StepTiming) []string {
+	names := make([]string, len(steps))
+	for i, step := range steps {
+		names[i] = step.Name
+	}
+	return names
+}
+
+func fileTimes(files []FileDetail) map[string]float64 {
+	times := make(map[string]float64)
+	for _, file := range files {
+		times[file.Path] += file.ProcessingTime
+	}
+	return times
+}
+
+// unionNames returns the names of every list without duplicates, keeping first-seen order
+func unionNames(lists ...[]string) []string {
+	seen := make(map[string]bool)
+	var names []string
+	for _, list := range lists {
+		for _, name := range list {
+			if !seen[name] {
+				seen[name] = true
+				names = append(names, name)
+			}
+		}
+	}
+	return names
+}
+
+func sortByAbsoluteChange(deltas []MetricDelta) {
+	sort.SliceStable(deltas, func(i, j int) bool {
+		return math.Abs(deltas[i].After-deltas[i].Before) > math.Abs(deltas[j].After-deltas[j].Before)
+	})
+}
+
+// deltaSection is one titled table of a comparison
+type deltaSection struct {
+	Title  string
+	Deltas []MetricDelta
+}
+
+// sections returns the comparison's tables in display order
+func (c ReportComparison) sections() []deltaSection {
+	return []deltaSection{
+		{"Overview", c.Overall},
+		{"Steps", c.Steps},
+		{"Changed bundles", c.Bundles},
+		{"Slower files", c.Files},
+	}
+}
+
+// Markdown renders the comparison as tables for a PR description
+func (c ReportComparison) Markdown() string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "## Build comparison: `%s` → `%s`\n\n", c.Before.Metrics.BuildID, c.After.Metrics.BuildID)
+	fmt.Fprintf(&sb, "- **Before:** `%s` (%s, profile `%s`, %s)\n", c.Before.Metrics.BuildID,
+		c.Before.Started().Format("2006-01-02 15:04"), c.Before.Metrics.Profile, c.Before.Outcome())
+	fmt.Fprintf(&sb, "- **After:** `%s` (%s, profile `%s`, %s)\n", c.After.Metrics.BuildID,
+		c.After.Started().Format("2006-01-02 15:04"), c.After.Metrics.Profile, c.After.Outcome())
+	fmt.Fprintf(&sb, "- Changes over %.0f%% are marked ⚠️ (worse) or ✅ (better)\n", compareThreshold*100)
+
+	for _, section := range c.sections() {
+		if len(section.Deltas) == 0 {
+			continue
+		}
+		fmt.Fprintf(&sb, "\n### %s\n\n| | Before | After | Change |\n|---|---:|---:|---:|\n", section.Title)
+		for _, d := range section.Deltas {
+			mark := ""
+			if d.Significant() {
+				mark = " ✅"
+				if d.Regressed() {
+					mark = " ⚠️"
+				}
+			}
+			fmt.Fprintf(&sb, "| %s | %s | %s | %s%s |\n",
+				strings.ReplaceAll(d.Label, "|", "\\|"), d.format(d.Before), d.format(d.After), d.DeltaString(), mark)
+		}
+	}
+	return sb.String()
+}
+
+// compareLines lays out the comparison as styled lines for scrolling
+func compareLines(c ReportComparison) []string {
+	lines := []string{
+		infoStyle.Render(fmt.Sprintf("Before: %s %s  %s  %s", outcomeIcon(c.Before.Outcome()), c.Before.Metrics.BuildID,
+			c.Before.Started().Format("2006-01-02 15:04"), c.Before.Metrics.Profile)),
+		infoStyle.Render(fmt.Sprintf("After:  %s %s  %s  %s", outcomeIcon(c.After.Outcome()), c.After.Metrics.BuildID,
+			c.After.Started().Format("2006-01-02 15:04"), c.After.Metrics.Profile)),
+	}
+
+	for _, section := range c.sections() {
+		if len(section.Deltas) == 0 {
+			continue
+		}
+		lines = append(lines, "", statsStyle.Render(section.Title+":"))
+		for _, d := range section.Deltas {
+			line := fmt.Sprintf("  %-28s %10s → %-10s %s", truncate(d.Label, 28), d.format(d.Before), d.format(d.After), d.DeltaString())
+			switch {
+			case d.Significant() && d.Regressed():
+				lines = append(lines, errorStyle.Render(line+"  ⚠️"))
+			case d.Significant():
+				lines = append(lines, selectedStyle.Render(line+"  ✅"))
+			default:
+				lines = append(lines, detailStyle.Render(line))
+			}
+		}
+	}
+	return lines
+}
+
+// openComparison compares the marked build with the selected one, oldest first
+func (m Model) openComparison() Model {
+	if m.historyCursor >= len(m.history) {
+		return m
+	}
+	var marked BuildReport
+	found := false
+	for _, report := range m.history {
+		if report.Metrics.BuildID == m.compareMark {
+			marked, found = report, true
+		}
+	}
+	selected := m.history[m.historyCursor]
+	if !found || marked.Metrics.BuildID == selected.Metrics.BuildID {
+		m.statusMessage = "Mark a build with space, then select another build and press c"
+		return m
+	}
+
+	// Full reports carry file details the history entries may have lost
+	for _, report := range []*BuildReport{&marked, &selected} {
+		if full, err := loadBuildReport(report.Metrics.BuildID); err == nil {
+			*report = full
+		}
+	}
+	before, after := marked, selected
+	if before.Started().After(after.Started()) {
+		before, after = after, before
+	}
+
+	m.comparison = compareReports(before, after)
+	m.compareScroll = 0
+	m.statusMessage = ""
+	m.state = StateCompare
+	return m
+}
+
+// exportComparison writes the comparison as Markdown into analytics-data and copies it to the clipboard
+func exportComparison(c ReportComparison) tea.Cmd {
+	return func() tea.Msg {
+		markdown := c.Markdown()
+		path := filepath.Join(analyticsDir(), fmt.Sprintf("compare-%s-%s.md", c.Before.Metrics.BuildID, c.After.Metrics.BuildID))
+		if err := os.WriteFile(path, []byte(markdown), 0o644); err != nil {
+			return URLActionMsg{Err: fmt.Errorf("failed to write %s: %v", path, err)}
+		}
+		return clipboardMsg{Text: markdown, Status: fmt.Sprintf("📝 Saved %s and copied it to the clipboard", filepath.Base(path))}
+	}
+}
+
+func (m Model) handleCompareKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "ctrl+c", "q":
+		m.backend.Cleanup()
+		return m, tea.Quit
+	case "esc":
+		m.state = StateHistory
+		m.statusMessage = ""
+	case "m":
+		return m, exportComparison(m.comparison)
+	default:
+		m.compareScroll = m.scrollLines(msg, m.compareScroll, len(compareLines(m.comparison)))
+	}
+	return m, nil
+}
+
+func (m Model) renderCompare() string {
+	s := "\n"
+	s += titleStyle.Render("⚖️  BUILD COMPARISON") + "\n\n"
+	s += m.renderScrolled(compareLines(m.comparison), m.compareScroll)
+
+	if m.statusMessage != "" {
+		s += "\n" + infoStyle.Render(m.statusMessage) + "\n"
+	}
+
+	s += "\n" + helpStyle.Render(fmt.Sprintf("↑/↓: scroll • m: export Markdown • esc: back to history • highlighted: changes over %.0f%%", compareThreshold*100)) + "\n"
+	return s
+}
@@ -0,0 +1,105 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+)
+
+// Two builds of the theme as build-analytics.js saves them: the first copies
+// everything, the second finds most files in the cache but copies a few that
+// changed, one of them much slower than before
+const (
+	coldBuildReport = `{"metrics": {
+		"buildId": "build-1714564800000-a1b2c3", "status": "success", "buildTime": 5120,
+		"filesProcessed": 6, "cacheHits": 0, "cacheMisses": 6, "cacheEfficiency": 0,
+		"fileDetails": [
+			{"path": "src/liquid/sections/product-hero.liquid", "processingTime": 1.82, "fromCache": false, "timestamp": 1714564801000},
+			{"path": "src/liquid/snippets/price.liquid", "processingTime": 0.94, "fromCache": false, "timestamp": 1714564801001},
+			{"path": "src/styles/css/main.css", "processingTime": 2.4, "fromCache": false, "timestamp": 1714564801002},
+			{"path": "src/fonts/DMSans-Bold.woff2", "processingTime": 3.1, "fromCache": false, "timestamp": 1714564801003},
+			{"path": "src/images/404-main-image.jpg", "processingTime": 12.6, "fromCache": false, "timestamp": 1714564801004},
+			{"path": "src/assets/dietitian-quiz.json", "processingTime": 0.71, "fromCache": false, "timestamp": 1714564801005}
+		]
+	}}`
+	warmBuildReport = `{"metrics": {
+		"buildId": "build-1714565400000-d4e5f6", "status": "success", "buildTime": 2480,
+		"filesProcessed": 6, "cacheHits": 4, "cacheMisses": 2, "cacheEfficiency": 67,
+		"fileDetails": [
+			{"path": "src/liquid/sections/product-hero.liquid", "processingTime": 48.3, "fromCache": false, "timestamp": 1714565401000},
+			{"path": "src/liquid/snippets/price.liquid", "processingTime": 0, "fromCache": true, "timestamp": 1714565401001},
+			{"path": "src/styles/css/main.css", "processingTime": 2.9, "fromCache": false, "timestamp": 1714565401002},
+			{"path": "src/fonts/DMSans-Bold.woff2", "processingTime": 0, "fromCache": true, "timestamp": 1714565401003},
+			{"path": "src/images/404-main-image.jpg", "processingTime": 0, "fromCache": true, "timestamp": 1714565401004},
+			{"path": "src/assets/dietitian-quiz.json", "processingTime": 0, "fromCache": true, "timestamp": 1714565401005}
+		]
+	}}`
+)
+
+func decodeReport(t *testing.T, data string) BuildReport {
+	t.Helper()
+	var report BuildReport
+	if err := json.Unmarshal([]byte(data), &report); err != nil {
+		t.Fatalf("failed to decode report: %v", err)
+	}
+	return report
+}
+
+func TestCompareReports(t *testing.T) {
+	cold, warm := decodeReport(t, coldBuildReport), decodeReport(t, warmBuildReport)
+
+	t.Run("slower files", func(t *testing.T) {
+		comparison := compareReports(cold, warm)
+		want := []MetricDelta{
+			{Label: "src/liquid/sections/product-hero.liquid", Unit: unitMillis, Before: 1.82, After: 48.3},
+			{Label: "src/styles/css/main.css", Unit: unitMillis, Before: 2.4, After: 2.9},
+		}
+		if len(comparison.Files) != len(want) {
+			t.Fatalf("got %d slower files %v, want %d", len(comparison.Files), comparison.Files, len(want))
+		}
+		for i := range want {
+			if comparison.Files[i] != want[i] {
+				t.Errorf("file %d = %+v, want %+v", i, comparison.Files[i], want[i])
+			}
+		}
+	})
+
+	t.Run("no slower files when the cache takes over", func(t *testing.T) {
+		if files := compareReports(warm, cold).Files; len(files) != 4 {
+			t.Errorf("got %d slower files, want the 4 that were cached before", len(files))
+		}
+	})
+
+	t.Run("cache hit rate", func(t *testing.T) {
+		for _, delta := range compareReports(cold, warm).Overall {
+			if delta.Label != "Cache hit rate" {
+				continue
+			}
+			if delta.Before != 0 || delta.After != 67 {
+				t.Errorf("cache hit rate %v → %v, want 0 → 67", delta.Before, delta.After)
+			}
+			if delta.Regressed() {
+				t.Error("a higher cache hit rate should not be a regression")
+			}
+			return
+		}
+		t.Error("no cache hit rate in the overall metrics")
+	})
+
+	t.Run("at most ten files", func(t *testing.T) {
+		before, after := cold, warm
+		before.Metrics.FileDetails, after.Metrics.FileDetails = nil, nil
+		for i := 0; i < 15; i++ {
+			path := fmt.Sprintf("src/liquid/snippets/icon-%02d.liquid", i)
+			before.Metrics.FileDetails = append(before.Metrics.FileDetails, FileDetail{Path: path, ProcessingTime: 1})
+			after.Metrics.FileDetails = append(after.Metrics.FileDetails, FileDetail{Path: path, ProcessingTime: float64(2 + i)})
+		}
+		files := compareReports(before, after).Files
+		if len(files) != 10 {
+			t.Fatalf("got %d slower files, want 10", len(files))
+		}
+		if files[0].Label != "src/liquid/snippets/icon-14.liquid" || files[9].Label != "src/liquid/snippets/icon-05.liquid" {
+			t.Errorf("files run %s … %s, want the biggest slowdowns first", files[0].Label, files[9].Label)
+		}
+	})
+}
@@ -0,0 +1,371 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Diagnostic is a single structured problem reported by one of the build tools
+type Diagnostic struct {
+	Severity  string `json:"severity"`
+	Tool      string `json:"tool"`
+	Check     string `json:"check,omitempty"` // rule name, for Theme Check, ESLint and the schema validator
+	File      string `json:"file"`
+	Line      int    `json:"line"`
+	Column    int    `json:"column"`
+	Message   string `json:"message"`
+	CodeFrame string `json:"codeFrame,omitempty"`
+}
+
+// Ref returns the diagnostic location as a FileRef for the editor
+func (d Diagnostic) Ref() FileRef {
+	return FileRef{Path: d.File, Line: d.Line, Column: d.Column}
+}
+
+// key identifies duplicate reports of the same problem
+func (d Diagnostic) key() string {
+	return fmt.Sprintf("%s|%s|%d|%d|%s", d.Tool, d.File, d.Line, d.Column, d.Message)
+}
+
+// DiagnosticGroup holds every diagnostic reported for one file
+type DiagnosticGroup struct {
+	File        string
+	Diagnostics []Diagnostic
+}
+
+// maxDiagnostics bounds the diagnostics kept by the backend
+const maxDiagnostics = 200
+
+// buildTools are the tools whose diagnostics come from build output, which a new build replaces
+var buildTools = map[string]bool{"vite": true, "liquid": true, "postcss": true, "tailwind": true}
+
+var (
+	// CssSyntaxError: /path/main.css:12:5: Unknown word
+	postcssPattern = regexp.MustCompile(`(?:CssSyntaxError|\[postcss\]|postcss)[^:]*:\s*(\S+?\.(?:css|pcss|scss)):(\d+):(\d+):\s*(.*)`)
+	// /path/main.css:12:5: The `foo` class does not exist
+	cssLocationPattern = regexp.MustCompile(`^\s*(\S+?\.(?:css|pcss|scss)):(\d+):(\d+):\s*(.*)`)
+	// /path/main.js:3:4: ERROR: Expected ";" but found "x"   (esbuild, via Vite)
+	esbuildPattern = regexp.MustCompile(`^\s*[✘▲]?\s*(\S+?\.(?:js|mjs|cjs|ts)):(\d+):(\d+):\s*(ERROR|WARNING|error|warning):\s*(.*)`)
+	// ✘ [ERROR] Expected ";" but found "x"   (esbuild's own format, the location follows on its own line)
+	esbuildHeaderPattern = regexp.MustCompile(`^\s*[✘▲]\s*\[(ERROR|WARNING)\]\s*(.*)`)
+	//     src/main.js:3:4:
+	esbuildLocationPattern = regexp.MustCompile(`^\s+(\S+?\.\w+):(\d+):(\d+):\s*$`)
+	// [vite]: Rollup failed to resolve import "x" from "src/main.js".
+	rollupResolvePattern = regexp.MustCompile(`(?:Rollup failed to resolve import|Could not resolve) "([^"]+)" from "([^"]+)"`)
+	// [vite:css] message  /  [plugin:vite:esbuild] message  /  RollupError: message
+	vitePluginPattern = regexp.MustCompile(`^\s*(?:error during build:\s*)?(?:\[(?:plugin:)?vite(?::[\w-]+)?\]:?|RollupError:)\s*(.*)`)
+	// file: /path/main.js:3:4   (location line Vite prints after the message)
+	viteFilePattern = regexp.MustCompile(`^\s*file:\s*(\S+?)(?::(\d+):(\d+))?\s*$`)
+	// Liquid syntax error (sections/hero.liquid line 14): Unknown tag 'foo'
+	liquidInlinePattern = regexp.MustCompile(`Liquid (syntax )?(error|warning)[^(]*\((?:([\w./-]+\.liquid)\s+)?line (\d+)\):?\s*(.*)`)
+	// sections/hero.liquid: Liquid syntax error (line 14): Unknown tag 'foo'
+	liquidPrefixPattern = regexp.MustCompile(`\[?([\w./-]+\.liquid)\]?:?\s+(?:.*?)Liquid (syntax )?(error|warning)(?:\s*\(line (\d+)\))?:?\s*(.*)`)
+	// Code frame lines: "  12 | code", "> 12 | code", "12: code", "     |   ^", and esbuild's "3 │ code", "  ╵ ;"
+	codeFramePattern = regexp.MustCompile(`^\s*>?\s*(\d+\s*[|:│]|[|│╵])\s?|^\s*\^+\s*$`)
+)
+
+// parseDiagnosticHeader recognises the first line of a Vite/Rollup, PostCSS/Tailwind or Shopify CLI error
+func parseDiagnosticHeader(line string) (Diagnostic, bool) {
+	if m := postcssPattern.FindStringSubmatch(line); m != nil {
+		return cssDiagnostic(m[1], m[2], m[3], m[4]), true
+	}
+	if m := esbuildHeaderPattern.FindStringSubmatch(line); m != nil {
+		d := Diagnostic{Severity: "error", Tool: "vite", Message: strings.TrimSpace(m[2])}
+		if m[1] == "WARNING" {
+			d.Severity = "warning"
+		}
+		return d, true
+	}
+	if m := esbuildPattern.FindStringSubmatch(line); m != nil {
+		d := Diagnostic{Severity: "error", Tool: "vite", File: m[1], Message: m[5]}
+		d.Line, _ = strconv.Atoi(m[2])
+		d.Column, _ = strconv.Atoi(m[3])
+		if strings.EqualFold(m[4], "warning") {
+			d.Severity = "warning"
+		}
+		return d, true
+	}
+	if m := cssLocationPattern.FindStringSubmatch(line); m != nil {
+		return cssDiagnostic(m[1], m[2], m[3], m[4]), true
+	}
+	if m := rollupResolvePattern.FindStringSubmatch(line); m != nil {
+		return Diagnostic{
+			Severity: "error",
+			Tool:     "vite",
+			File:     m[2],
+			Message:  fmt.Sprintf("Failed to resolve import %q", m[1]),
+		}, true
+	}
+	if m := liquidInlinePattern.FindStringSubmatch(line); m != nil {
+		d := Diagnostic{Severity: m[2], Tool: "liquid", File: m[3], Message: m[5]}
+		d.Line, _ = strconv.Atoi(m[4])
+		if d.File == "" {
+			if ref, ok := firstFileRef(line); ok {
+				d.File = ref.Path
+			}
+		}
+		return d, true
+	}
+	if m := liquidPrefixPattern.FindStringSubmatch(line); m != nil {
+		d := Diagnostic{Severity: m[3], Tool: "liquid", File: m[1], Message: m[5]}
+		d.Line, _ = strconv.Atoi(m[4])
+		return d, true
+	}
+	if m := vitePluginPattern.FindStringSubmatch(line); m != nil {
+		d := Diagnostic{Severity: "error", Tool: "vite", Message: strings.TrimSpace(m[1])}
+		if ref, ok := firstFileRef(m[1]); ok {
+			d.File, d.Line, d.Column = ref.Path, ref.Line, ref.Column
+		}
+		return d, true
+	}
+	return Diagnostic{}, false
+}
+
+// cssDiagnostic builds a PostCSS diagnostic, attributing Tailwind-specific messages to Tailwind
+func cssDiagnostic(file, line, column, message string) Diagnostic {
+	d := Diagnostic{Severity: "error", Tool: "postcss", File: file, Message: message}
+	d.Line, _ = strconv.Atoi(line)
+	d.Column, _ = strconv.Atoi(column)
+	if strings.Contains(message, "class does not exist") || strings.Contains(message, "@apply") ||
+		strings.Contains(message, "@tailwind") || strings.Contains(strings.ToLower(message), "tailwind") {
+		d.Tool = "tailwind"
+	}
+	return d
+}
+
+// diagnosticCollector turns a stream of output lines into diagnostics,
+// attaching code frames and Vite "file:" lines to the diagnostic above them
+type diagnosticCollector struct {
+	current *Diagnostic
+	frame   []string
+	done    []Diagnostic
+	// awaitLocation is set after an esbuild header, whose location comes after a blank line
+	awaitLocation bool
+}
+
+// Feed processes one line of output
+func (c *diagnosticCollector) Feed(line string) {
+	line = ansiPattern.ReplaceAllString(strings.TrimRight(line, "\r"), "")
+
+	if c.awaitLocation {
+		if strings.TrimSpace(line) == "" {
+			return
+		}
+		c.awaitLocation = false
+		if m := esbuildLocationPattern.FindStringSubmatch(line); m != nil {
+			c.current.File = m[1]
+			c.current.Line, _ = strconv.Atoi(m[2])
+			c.current.Column, _ = strconv.Atoi(m[3])
+			return
+		}
+	}
+
+	if d, ok := parseDiagnosticHeader(line); ok {
+		// Vite prints "[vite:esbuild] Transform failed with 1 error:" above the located error
+		if c.current != nil && c.current.File == "" && len(c.frame) == 0 && d.File != "" {
+			c.current = nil
+		}
+		c.Flush()
+		c.current = &d
+		c.awaitLocation = esbuildHeaderPattern.MatchString(line)
+		return
+	}
+	if c.current == nil {
+		return
+	}
+	if m := viteFilePattern.FindStringSubmatch(line); m != nil {
+		if c.current.File == "" || c.current.Line == 0 {
+			c.current.File = m[1]
+			c.current.Line, _ = strconv.Atoi(m[2])
+			c.current.Column, _ = strconv.Atoi(m[3])
+		}
+		return
+	}
+	if codeFramePattern.MatchString(line) {
+		c.frame = append(c.frame, line)
+		return
+	}
+	c.Flush()
+}
+
+// Flush completes the diagnostic being collected, if any
+func (c *diagnosticCollector) Flush() {
+	if c.current == nil {
+		return
+	}
+	c.current.CodeFrame = strings.Join(c.frame, "\n")
+	c.done = append(c.done, *c.current)
+	c.current = nil
+	c.frame = nil
+	c.awaitLocation = false
+}
+
+// Take returns the completed diagnostics and resets the list
+func (c *diagnosticCollector) Take() []Diagnostic {
+	done := c.done
+	c.done = nil
+	return done
+}
+
+// Strips colour codes before parsing
+var ansiPattern = regexp.MustCompile(`\x1b\[[0-9;]*[A-Za-z]`)
+
+// parseDiagnostics extracts every diagnostic from a (possibly multi-line) block of output
+func parseDiagnostics(text string) []Diagnostic {
+	var c diagnosticCollector
+	for _, line := range strings.Split(text, "\n") {
+		c.Feed(line)
+	}
+	c.Flush()
+	return c.Take()
+}
+
+// groupDiagnostics groups diagnostics by file, errors before warnings and files in name order
+func groupDiagnostics(diagnostics []Diagnostic) []DiagnosticGroup {
+	index := make(map[string]int)
+	var groups []DiagnosticGroup
+	for _, d := range diagnostics {
+		file := d.File
+		if file == "" {
+			file = "(no file)"
+		}
+		i, ok := index[file]
+		if !ok {
+			i = len(groups)
+			index[file] = i
+			groups = append(groups, DiagnosticGroup{File: file})
+		}
+		groups[i].Diagnostics = append(groups[i].Diagnostics, d)
+	}
+
+	sort.SliceStable(groups, func(i, j int) bool { return groups[i].File < groups[j].File })
+	for _, group := range groups {
+		sort.SliceStable(group.Diagnostics, func(i, j int) bool {
+			a, b := group.Diagnostics[i], group.Diagnostics[j]
+			if a.Severity != b.Severity {
+				return a.Severity == "error"
+			}
+			return a.Line < b.Line
+		})
+	}
+	return groups
+}
+
+// flattenGroups lists diagnostics in display order so the panel cursor can index them
+func flattenGroups(groups []DiagnosticGroup) []Diagnostic {
+	var flat []Diagnostic
+	for _, group := range groups {
+		flat = append(flat, group.Diagnostics...)
+	}
+	return flat
+}
+
+// countBySeverity returns the number of errors and warnings
+func countBySeverity(diagnostics []Diagnostic) (errors, warnings int) {
+	for _, d := range diagnostics {
+		if d.Severity == "error" {
+			errors++
+		} else {
+			warnings++
+		}
+	}
+	return errors, warnings
+}
+
+func (m Model) handleDiagnosticsKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	diagnostics := flattenGroups(groupDiagnostics(m.backend.GetDiagnostics()))
+	switch msg.String() {
+	case "ctrl+c", "q":
+		return m, tea.Quit
+	case "esc":
+		m.state = StateMenu
+	case "up", "k":
+		if m.diagCursor > 0 {
+			m.diagCursor--
+		}
+	case "down", "j":
+		if m.diagCursor < len(diagnostics)-1 {
+			m.diagCursor++
+		}
+	case "e", "enter":
+		if m.diagCursor < len(diagnostics) && diagnostics[m.diagCursor].File != "" {
+			return m, openInEditor(diagnostics[m.diagCursor].Ref())
+		}
+	case "c":
+		m.backend.ClearDiagnostics()
+		m.diagCursor = 0
+	}
+	return m, nil
+}
+
+func (m Model) renderDiagnostics() string {
+	diagnostics := m.backend.GetDiagnostics()
+	groups := groupDiagnostics(diagnostics)
+	errors, warnings := countBySeverity(diagnostics)
+
+	s := "\n"
+	s += titleStyle.Render("🩺 DIAGNOSTICS") + "\n\n"
+	s += statusStyle.Render(fmt.Sprintf("%d errors • %d warnings • %d files", errors, warnings, len(groups))) + "\n\n"
+
+	if len(diagnostics) == 0 {
+		s += detailStyle.Render("No problems reported by Vite, PostCSS/Tailwind, Liquid, Theme Check or the schema validator") + "\n\n"
+	}
+
+	// Keep the selected diagnostic in view
+	var lines []string
+	selectedLine := 0
+	var selected *Diagnostic
+	index := 0
+	for _, group := range groups {
+		if len(lines) > 0 {
+			lines = append(lines, "")
+		}
+		lines = append(lines, statsStyle.Render(fmt.Sprintf("📄 %s (%d)", group.File, len(group.Diagnostics))))
+		for _, d := range group.Diagnostics {
+			icon := "❌"
+			if d.Severity != "error" {
+				icon = "⚠️ "
+			}
+			location := ""
+			if d.Line > 0 {
+				location = fmt.Sprintf("%d:%d ", d.Line, d.Column)
+			}
+			tool := d.Tool
+			if d.Check != "" {
+				tool += " " + d.Check
+			}
+			line := truncate(fmt.Sprintf("%s %s[%s] %s", icon, location, tool, d.Message), max(m.width-4, 60))
+			if index == m.diagCursor {
+				lines = append(lines, selectedStyle.Render("> "+line))
+				selectedLine = len(lines) - 1
+				current := d
+				selected = &current
+			} else {
+				lines = append(lines, normalStyle.Render("  "+line))
+			}
+			index++
+		}
+	}
+	if len(lines) > 0 {
+		visible := max(m.visibleLines()-6, 5)
+		scroll := min(max(selectedLine-visible/2, 0), max(len(lines)-visible, 0))
+		s += m.renderScrolled(lines, scroll) + "\n"
+	}
+
+	if selected != nil && selected.CodeFrame != "" {
+		s += statsStyle.Render("🔎 Code frame:") + "\n"
+		s += detailStyle.Render(selected.CodeFrame) + "\n\n"
+	}
+
+	if m.statusMessage != "" {
+		s += infoStyle.Render(m.statusMessage) + "\n\n"
+	}
+
+	s += helpStyle.Render("↑/↓: navigate • e: open in editor • c: clear • esc: return to menu") + "\n"
+	return s
+}
@@ -0,0 +1,120 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseDiagnostics(t *testing.T) {
+	tests := []struct {
+		name   string
+		output string
+		want   []Diagnostic
+		frames []int // code frame line count of each diagnostic
+	}{
+		{
+			name: "vite esbuild transform error",
+			output: `[vite:esbuild] Transform failed with 1 error:
+/home/dev/theme/src/scripts/main.js:3:6: ERROR: Expected ";" but found "b"
+file: /home/dev/theme/src/scripts/main.js:3:6
+
+Expected ";" but found "b"`,
+			want:   []Diagnostic{{Severity: "error", Tool: "vite", File: "/home/dev/theme/src/scripts/main.js", Line: 3, Column: 6, Message: `Expected ";" but found "b"`}},
+			frames: []int{0},
+		},
+		{
+			name: "esbuild error with the location on its own line",
+			output: "\x1b[31m✘ \x1b[41;31m[\x1b[41;97mERROR\x1b[41;31m]\x1b[0m \x1b[1mExpected \";\" but found \"b\"\x1b[0m\n" + `
+    src/scripts/main.js:3:6:
+      3 │ let a b
+        │       ^
+        ╵       ;
+
+`,
+			want:   []Diagnostic{{Severity: "error", Tool: "vite", File: "src/scripts/main.js", Line: 3, Column: 6, Message: `Expected ";" but found "b"`}},
+			frames: []int{3},
+		},
+		{
+			name: "esbuild warning followed by an error",
+			output: `▲ [WARNING] Comparison with -0 using the "===" operator will also match 0 [equals-negative-zero]
+
+    src/scripts/cart.js:12:14:
+      12 │ if (total === -0) {
+         ╵               ~~
+
+✘ [ERROR] Could not resolve "swiper"
+
+    src/scripts/slider.js:1:19:
+      1 │ import Swiper from "swiper";
+        ╵                    ~~~~~~~~
+`,
+			want: []Diagnostic{
+				{Severity: "warning", Tool: "vite", File: "src/scripts/cart.js", Line: 12, Column: 14, Message: `Comparison with -0 using the "===" operator will also match 0 [equals-negative-zero]`},
+				{Severity: "error", Tool: "vite", File: "src/scripts/slider.js", Line: 1, Column: 19, Message: `Could not resolve "swiper"`},
+			},
+			frames: []int{2, 2},
+		},
+		{
+			name:   "rollup unresolved import",
+			output: `[vite]: Rollup failed to resolve import "swiper/bundle" from "/home/dev/theme/src/scripts/slider.js".`,
+			want:   []Diagnostic{{Severity: "error", Tool: "vite", File: "/home/dev/theme/src/scripts/slider.js", Message: `Failed to resolve import "swiper/bundle"`}},
+			frames: []int{0},
+		},
+		{
+			name: "postcss syntax error with code frame",
+			output: `CssSyntaxError: /home/dev/theme/src/styles/main.css:12:5: Unknown word
+  10 | .hero {
+  11 |   display: flex;
+> 12 |     colr red;
+     |     ^
+  13 | }`,
+			want:   []Diagnostic{{Severity: "error", Tool: "postcss", File: "/home/dev/theme/src/styles/main.css", Line: 12, Column: 5, Message: "Unknown word"}},
+			frames: []int{5},
+		},
+		{
+			name:   "tailwind unknown class",
+			output: "CssSyntaxError: /home/dev/theme/src/styles/main.css:4:3: The `bg-primry` class does not exist. If `bg-primry` is a custom class, make sure it is defined within a `@layer` directive.",
+			want: []Diagnostic{{Severity: "error", Tool: "tailwind", File: "/home/dev/theme/src/styles/main.css", Line: 4, Column: 3,
+				Message: "The `bg-primry` class does not exist. If `bg-primry` is a custom class, make sure it is defined within a `@layer` directive."}},
+			frames: []int{0},
+		},
+		{
+			name:   "liquid error with the file in the parentheses",
+			output: `Liquid syntax error (sections/hero.liquid line 14): Unknown tag 'endfo'`,
+			want:   []Diagnostic{{Severity: "error", Tool: "liquid", File: "sections/hero.liquid", Line: 14, Message: "Unknown tag 'endfo'"}},
+			frames: []int{0},
+		},
+		{
+			name:   "liquid warning prefixed with the file",
+			output: `[snippets/price.liquid] Liquid warning: Expected end_of_string but found pipe`,
+			want:   []Diagnostic{{Severity: "warning", Tool: "liquid", File: "snippets/price.liquid", Message: "Expected end_of_string but found pipe"}},
+			frames: []int{0},
+		},
+		{
+			name:   "unrelated output",
+			output: "✅ Build completed in 4.2s\n📁 Copied 312 files",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := parseDiagnostics(test.output)
+			if len(got) != len(test.want) {
+				t.Fatalf("got %d diagnostics %+v, want %d", len(got), got, len(test.want))
+			}
+			for i, want := range test.want {
+				frame := got[i].CodeFrame
+				got[i].CodeFrame = ""
+				if got[i] != want {
+					t.Errorf("diagnostic %d:\n got %+v\nwant %+v", i, got[i], want)
+				}
+				lines := 0
+				if frame != "" {
+					lines = len(strings.Split(frame, "\n"))
+				}
+				if lines != test.frames[i] {
+					t.Errorf("diagnostic %d: got a %d line code frame %q, want %d lines", i, lines, frame, test.frames[i])
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,233 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// FileRef points at a location inside a project file
+type FileRef struct {
+	Path   string `json:"path"`
+	Line   int    `json:"line"`
+	Column int    `json:"column"`
+}
+
+// String formats the reference as path:line:col
+func (r FileRef) String() string {
+	s := r.Path
+	if r.Line > 0 {
+		s += fmt.Sprintf(":%d", r.Line)
+		if r.Column > 0 {
+			s += fmt.Sprintf(":%d", r.Column)
+		}
+	}
+	return s
+}
+
+// EditorFinishedMsg is sent when the external editor process exits
+type EditorFinishedMsg struct {
+	Ref FileRef
+	Err error
+}
+
+// editorResolvedMsg carries a reference found on disk, ready for the editor
+type editorResolvedMsg struct {
+	Ref FileRef
+}
+
+// Matches path:line[:col] and "path (line:col)" as printed by Vite, PostCSS and the Shopify CLI
+var fileRefPattern = regexp.MustCompile(`((?:[A-Za-z]:)?[\w./\\@~-]*[\w-]+\.(?:liquid|json|js|mjs|cjs|ts|css|scss|pcss|svg))(?::(\d+)(?::(\d+))?|\s+\((\d+):(\d+)\))?`)
+
+// projectRoot returns the directory the adapters run in
+func projectRoot() string {
+	return filepath.Join("..", "..")
+}
+
+// parseFileRefs extracts every file reference mentioned in a line of output
+func parseFileRefs(text string) []FileRef {
+	var refs []FileRef
+	seen := make(map[string]bool)
+
+	for _, match := range fileRefPattern.FindAllStringSubmatch(text, -1) {
+		ref := FileRef{Path: strings.TrimPrefix(match[1], "./")}
+		if match[2] != "" {
+			ref.Line, _ = strconv.Atoi(match[2])
+			ref.Column, _ = strconv.Atoi(match[3])
+		} else if match[4] != "" {
+			ref.Line, _ = strconv.Atoi(match[4])
+			ref.Column, _ = strconv.Atoi(match[5])
+		}
+
+		// Skip URLs and package names that happen to end in a known extension
+		if strings.Contains(ref.Path, "//") || strings.HasPrefix(match[0], "http") {
+			continue
+		}
+		if seen[ref.String()] {
+			continue
+		}
+		seen[ref.String()] = true
+		refs = append(refs, ref)
+	}
+
+	return refs
+}
+
+// firstFileRef returns the most specific file reference in the text, preferring ones with a line number
+func firstFileRef(text string) (FileRef, bool) {
+	refs := parseFileRefs(text)
+	if len(refs) == 0 {
+		return FileRef{}, false
+	}
+	for _, ref := range refs {
+		if ref.Line > 0 {
+			return ref, true
+		}
+	}
+	return refs[0], true
+}
+
+// resolveFileRef turns a reference into a path that exists on disk.
+// The watch adapter only reports base names, so unknown paths are looked up under src/.
+func resolveFileRef(ref FileRef) (FileRef, error) {
+	root := projectRoot()
+	candidates := []string{ref.Path}
+	if !filepath.IsAbs(ref.Path) {
+		candidates = []string{filepath.Join(root, ref.Path), filepath.Join(root, "src", ref.Path)}
+	}
+
+	for _, candidate := range candidates {
+		if info, err := os.Stat(candidate); err == nil && !info.IsDir() {
+			ref.Path = candidate
+			return ref, nil
+		}
+	}
+
+	base := filepath.Base(ref.Path)
+	var found string
+	filepath.Walk(filepath.Join(root, "src"), func(path string, info os.FileInfo, err error) error {
+		if err != nil || found != "" {
+			return nil
+		}
+		if !info.IsDir() && info.Name() == base {
+			found = path
+			return filepath.SkipAll
+		}
+		return nil
+	})
+	if found == "" {
+		return ref, fmt.Errorf("file not found: %s", ref.Path)
+	}
+
+	ref.Path = found
+	return ref, nil
+}
+
+// editorCommand builds the command line for $VISUAL or $EDITOR at the referenced line
+func editorCommand(ref FileRef) *exec.Cmd {
+	editor := os.Getenv("VISUAL")
+	if editor == "" {
+		editor = os.Getenv("EDITOR")
+	}
+	fields := strings.Fields(editor)
+	if len(fields) == 0 {
+		fields = []string{"vi"}
+	}
+	name, args := fields[0], fields[1:]
+	line := ref.Line
+	if line == 0 {
+		line = 1
+	}
+	column := ref.Column
+	if column == 0 {
+		column = 1
+	}
+
+	switch filepath.Base(name) {
+	case "code", "code-insiders", "codium", "cursor":
+		args = append(args, "--wait", "--goto", fmt.Sprintf("%s:%d:%d", ref.Path, line, column))
+	case "subl", "zed":
+		args = append(args, "--wait", fmt.Sprintf("%s:%d:%d", ref.Path, line, column))
+	case "hx":
+		args = append(args, fmt.Sprintf("%s:%d:%d", ref.Path, line, column))
+	default:
+		// vi, vim, nvim, nano, emacs, micro and kak all understand +line
+		args = append(args, fmt.Sprintf("+%d", line), ref.Path)
+	}
+
+	return exec.Command(name, args...)
+}
+
+// openInEditor looks the file up off the UI goroutine, since that can mean
+// walking all of src, and then hands it to runEditor
+func openInEditor(ref FileRef) tea.Cmd {
+	return func() tea.Msg {
+		resolved, err := resolveFileRef(ref)
+		if err != nil {
+			return EditorFinishedMsg{Ref: ref, Err: err}
+		}
+		return editorResolvedMsg{Ref: resolved}
+	}
+}
+
+// runEditor suspends the TUI, runs the editor and resumes when it exits
+func runEditor(ref FileRef) tea.Cmd {
+	return tea.ExecProcess(editorCommand(ref), func(err error) tea.Msg {
+		return EditorFinishedMsg{Ref: ref, Err: err}
+	})
+}
+
+// feedItem is a selectable entry in the watch/build feed that points at a file
+type feedItem struct {
+	Label string
+	Level string
+	Ref   FileRef
+}
+
+// maxFeedItems bounds how many feed entries the watch and build screens show
+const maxFeedItems = 8
+
+// watchFeed lists the newest file changes followed by log lines that mention a file
+func watchFeed(changes []ChangeEntry, logs []LogEntry) []feedItem {
+	var items []feedItem
+	for i := len(changes) - 1; i >= 0 && len(items) < maxFeedItems; i-- {
+		change := changes[i]
+		label := fmt.Sprintf("%s %s %s", change.Timestamp.Format("15:04:05"), change.Action, change.File)
+		if change.Impact != nil {
+			label += " → " + change.Impact.String()
+		}
+		items = append(items, feedItem{
+			Label: label,
+			Level: "info",
+			Ref:   FileRef{Path: change.File},
+		})
+	}
+	return append(items, logFeed(logs, maxFeedItems)...)
+}
+
+// logFeed lists the newest warning and error log lines that reference a file
+func logFeed(logs []LogEntry, limit int) []feedItem {
+	var items []feedItem
+	for i := len(logs) - 1; i >= 0 && len(items) < limit; i-- {
+		entry := logs[i]
+		if entry.Level != "error" && entry.Level != "warning" {
+			continue
+		}
+		ref, ok := firstFileRef(entry.Message)
+		if !ok {
+			continue
+		}
+		items = append(items, feedItem{
+			Label: fmt.Sprintf("%s %s", entry.Timestamp.Format("15:04:05"), entry.Message),
+			Level: entry.Level,
+			Ref:   ref,
+		})
+	}
+	return items
+}
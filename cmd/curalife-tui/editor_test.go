@@ -0,0 +1,102 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseFileRefs(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want []FileRef
+	}{
+		{
+			name: "path with line and column",
+			text: "Liquid syntax error in src/liquid/snippets/price.liquid:12:5: unknown tag",
+			want: []FileRef{{Path: "src/liquid/snippets/price.liquid", Line: 12, Column: 5}},
+		},
+		{
+			name: "parenthesized position as printed by PostCSS",
+			text: "./src/styles/main.css (3:14) Unknown word",
+			want: []FileRef{{Path: "src/styles/main.css", Line: 3, Column: 14}},
+		},
+		{
+			name: "base name without a line",
+			text: "✅ Updated: product-card.liquid",
+			want: []FileRef{{Path: "product-card.liquid"}},
+		},
+		{
+			name: "duplicates are reported once",
+			text: "app.js:4 imported by app.js:4 and main.js:9",
+			want: []FileRef{{Path: "app.js", Line: 4}, {Path: "main.js", Line: 9}},
+		},
+		{
+			name: "URLs are skipped",
+			text: "GET https://cdn.shopify.com/s/files/theme.js failed",
+			want: nil,
+		},
+		{
+			name: "no file mentioned",
+			text: "Build completed in 3.2s",
+			want: nil,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := parseFileRefs(test.text); !reflect.DeepEqual(got, test.want) {
+				t.Errorf("parseFileRefs(%q) = %v, want %v", test.text, got, test.want)
+			}
+		})
+	}
+}
+
+func TestEditorCommand(t *testing.T) {
+	tests := []struct {
+		name   string
+		visual string
+		editor string
+		ref    FileRef
+		want   []string
+	}{
+		{
+			name:   "VS Code jumps to line and column",
+			visual: "code",
+			ref:    FileRef{Path: "a.liquid", Line: 3, Column: 7},
+			want:   []string{"code", "--wait", "--goto", "a.liquid:3:7"},
+		},
+		{
+			name:   "EDITOR with arguments when VISUAL is unset",
+			editor: "nvim -u NONE",
+			ref:    FileRef{Path: "a.liquid", Line: 3},
+			want:   []string{"nvim", "-u", "NONE", "+3", "a.liquid"},
+		},
+		{
+			name:   "VISUAL wins over EDITOR",
+			visual: "hx",
+			editor: "nano",
+			ref:    FileRef{Path: "a.js", Line: 2, Column: 4},
+			want:   []string{"hx", "a.js:2:4"},
+		},
+		{
+			name:   "vi when EDITOR is only whitespace",
+			editor: "  ",
+			ref:    FileRef{Path: "a.css", Line: 5},
+			want:   []string{"vi", "+5", "a.css"},
+		},
+		{
+			name: "vi at the first line when nothing is set",
+			ref:  FileRef{Path: "a.css"},
+			want: []string{"vi", "+1", "a.css"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Setenv("VISUAL", test.visual)
+			t.Setenv("EDITOR", test.editor)
+			if got := editorCommand(test.ref).Args; !reflect.DeepEqual(got, test.want) {
+				t.Errorf("editorCommand(%v) args = %q, want %q", test.ref, got, test.want)
+			}
+		})
+	}
+}
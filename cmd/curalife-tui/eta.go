@@ -0,0 +1,176 @@
+package main
+
+import (
+	"fmt"
+	"time"
+)
+
+// adapterSteps maps build-adapter.js progress phases to the step names recorded in build reports
+var adapterSteps = map[string]string{
+	"init":     "init",
+	"copy":     "File Copy",
+	"styles":   "Tailwind CSS",
+	"scripts":  "Vite Build",
+	"optimize": "Optimization",
+}
+
+// declaredStepWeights are the static weights from build-adapter.js, used until there is history
+var declaredStepWeights = []StepEstimate{
+	{Name: "File Copy", Weight: 40},
+	{Name: "Tailwind CSS", Weight: 30},
+	{Name: "Vite Build", Weight: 30},
+}
+
+// StepEstimate is the expected share of a build taken by one step. With
+// history the weight is the step's median duration in milliseconds.
+type StepEstimate struct {
+	Name   string
+	Weight float64
+}
+
+// BuildEstimate predicts how a build splits into steps
+type BuildEstimate struct {
+	Steps []StepEstimate
+	// Samples is the number of past builds the weights were learned from; 0 means declared weights
+	Samples int
+}
+
+// FromHistory reports whether the weights are real durations, which makes an ETA possible
+func (e BuildEstimate) FromHistory() bool {
+	return e.Samples > 0
+}
+
+// estimateBuild learns per-step durations from the most recent successful
+// builds of the profile, or of any profile when the profile has no history yet
+func estimateBuild(history []BuildReport, profile string) BuildEstimate {
+	var samples []BuildReport
+	for _, sameProfile := range []bool{true, false} {
+		for _, report := range history {
+			if len(samples) == regressionWindow {
+				break
+			}
+			if report.Outcome() == "success" && len(report.Metrics.Steps) > 0 &&
+				(!sameProfile || report.Metrics.Profile == profile) {
+				samples = append(samples, report)
+			}
+		}
+		if len(samples) > 0 {
+			break
+		}
+	}
+	if len(samples) == 0 {
+		return BuildEstimate{Steps: declaredStepWeights}
+	}
+
+	// Step order follows the newest build; each weight is the median over the samples
+	durations := make(map[string][]float64)
+	for _, report := range samples {
+		for name, duration := range stepDurations(report.Metrics.Steps) {
+			durations[name] = append(durations[name], duration)
+		}
+	}
+	estimate := BuildEstimate{Samples: len(samples)}
+	for _, name := range unionNames(stepNames(samples[0].Metrics.Steps)) {
+		estimate.Steps = append(estimate.Steps, StepEstimate{Name: name, Weight: medianOf(durations[name])})
+	}
+	return estimate
+}
+
+// Progress returns the overall completion between 0 and 1, given the current
+// step and the fraction of it that is done
+func (e BuildEstimate) Progress(step string, fraction float64) float64 {
+	total, done := 0.0, 0.0
+	reached := false
+	for _, s := range e.Steps {
+		total += s.Weight
+		switch {
+		case s.Name == step:
+			done += s.Weight * min(max(fraction, 0), 1)
+			reached = true
+		case !reached:
+			done += s.Weight
+		}
+	}
+	if total == 0 || !reached {
+		return 0
+	}
+	return done / total
+}
+
+// Has reports whether the step carries a weight in the estimate
+func (e BuildEstimate) Has(step string) bool {
+	for _, s := range e.Steps {
+		if s.Name == step {
+			return true
+		}
+	}
+	return false
+}
+
+// Expected returns the learned duration of a step
+func (e BuildEstimate) Expected(step string) (time.Duration, bool) {
+	if !e.FromHistory() {
+		return 0, false
+	}
+	for _, s := range e.Steps {
+		if s.Name == step {
+			return time.Duration(s.Weight) * time.Millisecond, true
+		}
+	}
+	return 0, false
+}
+
+// Remaining estimates the time left: the rest of the current step plus every later step
+func (e BuildEstimate) Remaining(step string, inStep time.Duration) (time.Duration, bool) {
+	if !e.FromHistory() {
+		return 0, false
+	}
+	remaining := time.Duration(0)
+	reached := false
+	for _, s := range e.Steps {
+		expected := time.Duration(s.Weight) * time.Millisecond
+		switch {
+		case s.Name == step:
+			remaining += max(expected-inStep, 0)
+			reached = true
+		case reached:
+			remaining += expected
+		}
+	}
+	return remaining, reached
+}
+
+// stepFraction combines the step's reported progress with the share of its learned duration already spent
+func (s BuildStatus) stepFraction(now time.Time) float64 {
+	fraction := float64(s.StepProgress) / 100
+	if expected, ok := s.Estimate.Expected(s.StepName); ok && expected > 0 && !s.StepStartedAt.IsZero() {
+		// Stay short of done until the adapter says the step finished
+		elapsed := float64(now.Sub(s.StepStartedAt)) / float64(expected)
+		fraction = max(fraction, min(elapsed, 0.95))
+	}
+	return fraction
+}
+
+// OverallProgress is the step-weighted build progress in percent
+func (s BuildStatus) OverallProgress(now time.Time) int {
+	if !s.IsRunning || s.StepName == "" {
+		return s.Progress
+	}
+	return min(int(s.Estimate.Progress(s.StepName, s.stepFraction(now))*100), 99)
+}
+
+// ETA returns the expected time left for a running build
+func (s BuildStatus) ETA(now time.Time) (time.Duration, bool) {
+	if !s.IsRunning || s.StepName == "" || s.StepStartedAt.IsZero() {
+		return 0, false
+	}
+	return s.Estimate.Remaining(s.StepName, now.Sub(s.StepStartedAt))
+}
+
+// EstimateSource describes where the progress weights came from
+func (s BuildStatus) EstimateSource() string {
+	if s.Estimate.FromHistory() {
+		return fmt.Sprintf("learned from %d previous builds", s.Estimate.Samples)
+	}
+	return "default step weights"
+}
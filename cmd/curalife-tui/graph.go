@@ -0,0 +1,387 @@
+package main
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// ThemeGraph is the render graph of the theme: which files render, include
+// or reference which other files and assets
+type ThemeGraph struct {
+	Index   ThemeIndex
+	BuiltAt time.Time
+	files   map[string]ThemeFile
+	uses    map[string][]string // by node, the nodes it uses
+	usedBy  map[string][]string // by node, the nodes that use it
+}
+
+// assetNode is the graph node of an asset, which has no Liquid file of its own
+func assetNode(name string) string {
+	return "asset:" + name
+}
+
+// newThemeGraph links every reference in the index that resolves to a theme file or asset
+func newThemeGraph(index ThemeIndex) *ThemeGraph {
+	g := &ThemeGraph{
+		Index:   index,
+		BuiltAt: time.Now(),
+		files:   make(map[string]ThemeFile, len(index.Files)),
+		uses:    make(map[string][]string),
+		usedBy:  make(map[string][]string),
+	}
+	for _, file := range index.Files {
+		g.files[file.Path] = file
+	}
+	for _, file := range index.Files {
+		seen := make(map[string]bool)
+		for _, ref := range index.Refs[file.Path] {
+			node := ""
+			if target, ok := index.Resolve(ref); ok {
+				node = target.Path
+			} else if _, ok := index.Assets[ref.Name]; ok && ref.Kind == "asset" {
+				node = assetNode(ref.Name)
+			}
+			if node == "" || node == file.Path || seen[node] {
+				continue
+			}
+			seen[node] = true
+			g.uses[file.Path] = append(g.uses[file.Path], node)
+			g.usedBy[node] = append(g.usedBy[node], file.Path)
+		}
+	}
+	for _, edges := range []map[string][]string{g.uses, g.usedBy} {
+		for _, nodes := range edges {
+			sort.Strings(nodes)
+		}
+	}
+	return g
+}
+
+// Label names a node the way Liquid refers to it, e.g. "snippet price"
+func (g *ThemeGraph) Label(node string) string {
+	if name, ok := strings.CutPrefix(node, "asset:"); ok {
+		return "asset " + name
+	}
+	if file, ok := g.files[node]; ok {
+		return file.Kind + " " + file.Name
+	}
+	return node
+}
+
+// Uses returns the files and assets a node renders or references directly
+func (g *ThemeGraph) Uses(node string) []string {
+	return g.uses[node]
+}
+
+// UsedBy returns the files that render or reference a node directly
+func (g *ThemeGraph) UsedBy(node string) []string {
+	return g.usedBy[node]
+}
+
+// NodeFor finds the node of a changed file. Watch events name files by base
+// name, so Liquid files are resolved to their src path the way the editor does.
+func (g *ThemeGraph) NodeFor(name string) (string, bool) {
+	base := filepath.Base(name)
+	if _, ok := g.Index.Assets[base]; ok && filepath.Ext(base) != ".liquid" {
+		return assetNode(base), true
+	}
+	if ref, err := resolveFileRef(FileRef{Path: name}); err == nil {
+		if relative, err := filepath.Rel(projectRoot(), ref.Path); err == nil {
+			if _, ok := g.files[filepath.ToSlash(relative)]; ok {
+				return filepath.ToSlash(relative), true
+			}
+		}
+	}
+	stem := strings.TrimSuffix(base, filepath.Ext(base))
+	for _, kind := range []string{"snippet", "section", "block", "layout"} {
+		if file, ok := g.Index.Lookup(kind, stem); ok {
+			return file.Path, true
+		}
+	}
+	return "", false
+}
+
+// GraphLine is one row of a dependency tree
+type GraphLine struct {
+	Node  string
+	Depth int
+	// Repeat marks a node already shown higher up, whose subtree is not repeated
+	Repeat bool
+}
+
+// Tree lays out everything a node uses, or everything that uses it, depth first
+func (g *ThemeGraph) Tree(node string, usedBy bool) []GraphLine {
+	edges := g.uses
+	if usedBy {
+		edges = g.usedBy
+	}
+	var lines []GraphLine
+	shown := map[string]bool{node: true}
+	var walk func(node string, depth int)
+	walk = func(node string, depth int) {
+		for _, next := range edges[node] {
+			if shown[next] {
+				lines = append(lines, GraphLine{Node: next, Depth: depth, Repeat: true})
+				continue
+			}
+			shown[next] = true
+			lines = append(lines, GraphLine{Node: next, Depth: depth})
+			walk(next, depth+1)
+		}
+	}
+	walk(node, 0)
+	return lines
+}
+
+// ChangeImpact counts the sections and templates a changed file ends up in
+type ChangeImpact struct {
+	Sections  int  `json:"sections"`
+	Templates int  `json:"templates"`
+	Layout    bool `json:"layout"` // a layout uses it, so every page does
+}
+
+// String summarizes the impact for the change feed
+func (i ChangeImpact) String() string {
+	s := fmt.Sprintf("%d sections, %d templates", i.Sections, i.Templates)
+	if i.Layout {
+		s += ", layout"
+	}
+	return s
+}
+
+// Impact follows a node up the graph to the sections, templates and layouts
+// that render it. Templates without a layout of their own use theme.liquid, so
+// a change reaching a layout counts every template.
+func (g *ThemeGraph) Impact(node string) ChangeImpact {
+	var impact ChangeImpact
+	count := func(node string) {
+		switch g.files[node].Kind {
+		case "section":
+			impact.Sections++
+		case "template":
+			impact.Templates++
+		case "layout":
+			impact.Layout = true
+		}
+	}
+	count(node)
+	for _, line := range g.Tree(node, true) {
+		if !line.Repeat {
+			count(line.Node)
+		}
+	}
+	if impact.Layout {
+		impact.Templates = 0
+		for _, file := range g.Index.Files {
+			if file.Kind == "template" {
+				impact.Templates++
+			}
+		}
+	}
+	return impact
+}
+
+// themeGraph returns the cached render graph, rebuilding it when it predates since
+func (b *Backend) themeGraph(since time.Time) *ThemeGraph {
+	b.graphMutex.Lock()
+	defer b.graphMutex.Unlock()
+	if b.graph == nil || b.graph.BuiltAt.Before(since) {
+		b.graph = newThemeGraph(buildThemeIndex())
+	}
+	return b.graph
+}
+
+// annotateChange works out the impact of a changed file in the background and
+// adds it to the change feed entry. Liquid edits can change the graph itself,
+// so they rebuild it; a removed file is looked up in the graph it was still in.
+func (b *Backend) annotateChange(entry ChangeEntry) {
+	since := time.Time{}
+	if filepath.Ext(entry.File) == ".liquid" && entry.Action != "unlink" && entry.Action != "delete" {
+		since = entry.Timestamp
+	}
+	graph := b.themeGraph(since)
+	node, ok := graph.NodeFor(entry.File)
+	if !ok {
+		return
+	}
+	impact := graph.Impact(node)
+
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	for i := len(b.changes) - 1; i >= 0; i-- {
+		change := &b.changes[i]
+		if change.File == entry.File && change.Timestamp.Equal(entry.Timestamp) {
+			change.Impact = &impact
+			return
+		}
+	}
+}
+
+// graphFiles lists the theme files matching the search, best matches first
+func graphFiles(graph *ThemeGraph, query string) []ThemeFile {
+	if graph == nil {
+		return nil
+	}
+	type scored struct {
+		file  ThemeFile
+		score int
+	}
+	var matches []scored
+	for _, file := range graph.Index.Files {
+		if score, ok := fuzzyScore(query, file.Name); ok {
+			matches = append(matches, scored{file, score})
+		}
+	}
+	if query != "" {
+		sort.SliceStable(matches, func(i, j int) bool { return matches[i].score > matches[j].score })
+	}
+	files := make([]ThemeFile, len(matches))
+	for i, match := range matches {
+		files[i] = match.file
+	}
+	return files
+}
+
+func (m Model) openGraph() Model {
+	m.state = StateGraph
+	m.graph = m.backend.themeGraph(time.Now())
+	m.graphCursor = 0
+	m.graphQuery = ""
+	m.searchingGraph = false
+	m.statusMessage = ""
+	return m
+}
+
+func (m Model) handleGraphKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	files := graphFiles(m.graph, m.graphQuery)
+
+	if m.searchingGraph {
+		switch msg.Type {
+		case tea.KeyEnter, tea.KeyEsc:
+			m.searchingGraph = false
+		case tea.KeyBackspace:
+			if len(m.graphQuery) > 0 {
+				runes := []rune(m.graphQuery)
+				m.graphQuery = string(runes[:len(runes)-1])
+			}
+			m.graphCursor = 0
+		case tea.KeyRunes, tea.KeySpace:
+			m.graphQuery += string(msg.Runes)
+			m.graphCursor = 0
+		case tea.KeyUp, tea.KeyDown:
+			m.searchingGraph = false
+			return m.handleGraphKeys(msg)
+		}
+		return m, nil
+	}
+
+	switch msg.String() {
+	case "ctrl+c", "q":
+		m.backend.Cleanup()
+		return m, tea.Quit
+	case "esc":
+		if m.graphQuery != "" {
+			m.graphQuery = ""
+			m.graphCursor = 0
+		} else {
+			m.state = StateMenu
+		}
+	case "/":
+		m.searchingGraph = true
+	case "up", "k":
+		if m.graphCursor > 0 {
+			m.graphCursor--
+		}
+	case "down", "j":
+		if m.graphCursor < len(files)-1 {
+			m.graphCursor++
+		}
+	case "tab":
+		m.graphUses = !m.graphUses
+	case "e", "enter":
+		if m.graphCursor < len(files) {
+			return m, openInEditor(FileRef{Path: filepath.Join(projectRoot(), files[m.graphCursor].Path)})
+		}
+	case "r":
+		return m.openGraph(), nil
+	}
+	return m, nil
+}
+
+func (m Model) renderGraph() string {
+	s := "\n"
+	s += titleStyle.Render("🕸️  DEPENDENCY GRAPH") + "\n\n"
+
+	search := m.graphQuery
+	if m.searchingGraph {
+		search += "█"
+	}
+	if search != "" || m.searchingGraph {
+		s += infoStyle.Render(fmt.Sprintf("🔍 %s", search)) + "\n\n"
+	}
+
+	files := graphFiles(m.graph, m.graphQuery)
+	if m.graph != nil && !m.graph.Index.Templates {
+		s += detailStyle.Render(fmt.Sprintf("No JSON templates in %s/templates — template counts need a Shopify pull", buildDirName)) + "\n"
+	}
+
+	// Keep the cursor visible in a window of files
+	const visible = 10
+	start := 0
+	if m.graphCursor >= visible {
+		start = m.graphCursor - visible + 1
+	}
+	for i := start; i < len(files) && i < start+visible; i++ {
+		file := files[i]
+		line := fmt.Sprintf("%-8s %-40s uses %-3d used by %-3d %s", file.Kind, truncate(file.Name, 40),
+			len(m.graph.Uses(file.Path)), len(m.graph.UsedBy(file.Path)), file.Path)
+		line = truncate(line, max(m.width-4, 60))
+		if i == m.graphCursor {
+			s += selectedStyle.Render("> "+line) + "\n"
+		} else {
+			s += normalStyle.Render("  "+line) + "\n"
+		}
+	}
+	if len(files) == 0 {
+		s += detailStyle.Render("No matching files") + "\n"
+	}
+
+	if m.graphCursor < len(files) {
+		file := files[m.graphCursor]
+		title := "⬆️  Who uses " + file.Name
+		if m.graphUses {
+			title = "⬇️  What " + file.Name + " pulls in"
+		}
+		s += "\n" + statsStyle.Render(title) + "  " + detailStyle.Render(m.graph.Impact(file.Path).String()) + "\n"
+
+		tree := m.graph.Tree(file.Path, !m.graphUses)
+		room := max(m.visibleLines()-visible-8, 5)
+		for i, line := range tree {
+			if i == room {
+				s += detailStyle.Render(fmt.Sprintf("  … %d more", len(tree)-room)) + "\n"
+				break
+			}
+			text := strings.Repeat("  ", line.Depth+1) + "└ " + m.graph.Label(line.Node)
+			if line.Repeat {
+				s += detailStyle.Render(text+" (see above)") + "\n"
+			} else {
+				s += normalStyle.Render(text) + "\n"
+			}
+		}
+		if len(tree) == 0 {
+			s += detailStyle.Render("  Nothing") + "\n"
+		}
+	}
+
+	if m.statusMessage != "" {
+		s += "\n" + infoStyle.Render(m.statusMessage) + "\n"
+	}
+
+	s += "\n" + helpStyle.Render("/: search • ↑/↓: select • tab: who uses it / what it pulls in • e: open • r: rebuild • esc: return to menu") + "\n"
+	return s
+}
@@ -0,0 +1,182 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"syscall"
+)
+
+// Exit codes of headless commands
+const (
+	exitOK         = 0
+	exitFailed     = 1
+	exitUsage      = 2
+	exitRegression = 3
+)
+
+// runCommand runs a headless subcommand such as `curalife-tui build --json` and returns the exit code
+func runCommand(args []string) int {
+	switch args[0] {
+	case "build":
+		return runBuildCommand(args[1:])
+	case "unused":
+		return runUnusedCommand(args[1:])
+	case "validate":
+		return runValidateCommand(args[1:])
+	case "help", "-h", "--help":
+		printUsage(os.Stdout)
+		return exitOK
+	}
+	fmt.Fprintf(os.Stderr, "unknown command %q\n\n", args[0])
+	printUsage(os.Stderr)
+	return exitUsage
+}
+
+func printUsage(w io.Writer) {
+	fmt.Fprintln(w, "Usage: curalife-tui [command] [flags]")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Without a command the interactive TUI starts. Commands:")
+	fmt.Fprintln(w, "  build    Run a build without the TUI and check it for duration regressions")
+	fmt.Fprintln(w, "  unused   List snippets, sections and assets nothing uses, optionally moving them to backups/")
+	fmt.Fprintln(w, "  validate Check the {% schema %} of every section")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Run `curalife-tui <command> -h` for the flags of a command.")
+}
+
+// BuildResult is the headless build outcome printed by `build --json`
+type BuildResult struct {
+	Success     bool              `json:"success"`
+	ExitCode    int               `json:"exitCode"`
+	BuildID     string            `json:"buildId,omitempty"`
+	Profile     string            `json:"profile"`
+	DurationMs  int64             `json:"durationMs"`
+	Files       int               `json:"filesCopied"`
+	CacheHits   int               `json:"cacheHits"`
+	Errors      int               `json:"errors"`
+	Warnings    int               `json:"warnings"`
+	Regression  *RegressionResult `json:"regression,omitempty"`
+	Peaks       *SystemData       `json:"peaks,omitempty"`
+	Output      *OutputDiff       `json:"output,omitempty"`
+	Diagnostics []Diagnostic      `json:"diagnostics,omitempty"`
+}
+
+func runBuildCommand(args []string) int {
+	flags := flag.NewFlagSet("build", flag.ContinueOnError)
+	jsonOutput := flags.Bool("json", false, "print the result as JSON")
+	profile := flags.String("profile", "default", "build profile (built-in or saved)")
+	report := flags.Bool("report", false, "generate a bundle report")
+	assets := flags.Bool("assets", false, "force asset optimization")
+	noAssets := flags.Bool("no-assets", false, "skip asset optimization")
+	noCache := flags.Bool("no-cache", false, "disable the build cache")
+	autoOptimize := flags.Bool("auto-optimize", false, "use the auto-optimizing workflow")
+	failOnRegression := flags.Bool("fail-on-regression", false,
+		fmt.Sprintf("exit with %d when the build is a duration regression", exitRegression))
+	if err := flags.Parse(args); err == flag.ErrHelp {
+		return exitOK
+	} else if err != nil {
+		return exitUsage
+	}
+
+	options := DefaultBuildOptions()
+	for _, saved := range loadProfiles() {
+		if saved.Name == *profile {
+			options = saved.Options
+		}
+	}
+	options.Profile = *profile
+	options.Report = options.Report || *report
+	switch {
+	case *noAssets:
+		options.Assets = AssetsOff
+	case *assets:
+		options.Assets = AssetsOn
+	}
+	options.Cache = options.Cache && !*noCache
+	options.AutoOptimize = options.AutoOptimize || *autoOptimize
+
+	backend := NewBackend()
+	go func() {
+		sigChan := make(chan os.Signal, 1)
+		signal.Notify(sigChan, os.Interrupt, syscall.SIGTERM)
+		<-sigChan
+		backend.Cleanup()
+	}()
+
+	if err := backend.StartBuild(options); err != nil {
+		fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+		return exitFailed
+	}
+	job, _ := backend.jobs.Wait("build")
+	status := backend.GetBuildStatus()
+
+	result := BuildResult{
+		Success:     job.State == JobSucceeded,
+		ExitCode:    job.ExitCode,
+		BuildID:     status.ReportID,
+		Profile:     options.Profile,
+		DurationMs:  status.Duration,
+		Files:       status.FilesCopied,
+		CacheHits:   status.CacheHits,
+		Diagnostics: backend.GetDiagnostics(),
+	}
+	if result.DurationMs == 0 {
+		result.DurationMs = job.FinishedAt.Sub(job.StartedAt).Milliseconds()
+	}
+	result.Errors, result.Warnings = countBySeverity(result.Diagnostics)
+	if status.Peaks.ProcessCount > 0 {
+		result.Peaks = &status.Peaks
+	}
+	if diff, ok := backend.GetOutputDiff(); ok {
+		result.Output = &diff
+	}
+	if result.BuildID != "" {
+		if regression, ok, err := checkBuildRegression(result.BuildID); err == nil && ok {
+			result.Regression = &regression
+		}
+	}
+
+	if *jsonOutput {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		encoder.Encode(result)
+	} else {
+		printBuildResult(result, job)
+	}
+
+	switch {
+	case !result.Success:
+		return exitFailed
+	case *failOnRegression && result.Regression != nil && result.Regression.Regressed:
+		return exitRegression
+	}
+	return exitOK
+}
+
+func printBuildResult(result BuildResult, job Job) {
+	for _, line := range job.Logs {
+		fmt.Println(line)
+	}
+	if result.Success {
+		fmt.Printf("✅ Build %s succeeded in %s (profile %s)\n", result.BuildID, formatMillis(result.DurationMs), result.Profile)
+	} else {
+		fmt.Printf("❌ Build failed with exit code %d\n", result.ExitCode)
+	}
+	if result.Errors+result.Warnings > 0 {
+		fmt.Printf("🩺 %d errors, %d warnings\n", result.Errors, result.Warnings)
+	}
+	if result.Peaks != nil {
+		fmt.Println("🏔️  Peak " + result.Peaks.Summary())
+	}
+	switch {
+	case result.Regression == nil:
+		fmt.Println("📉 Not enough history for a regression baseline yet")
+	case result.Regression.Regressed:
+		fmt.Println("🐢 Duration regression: " + result.Regression.Summary())
+	default:
+		fmt.Println("📈 No regression: " + result.Regression.Summary())
+	}
+}
@@ -0,0 +1,513 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// BuildReport is one report-<buildId>.json written by build-analytics.js
+type BuildReport struct {
+	Metrics  BuildMetrics  `json:"metrics"`
+	Analysis BuildAnalysis `json:"analysis"`
+}
+
+// BuildMetrics are the raw measurements of one build
+type BuildMetrics struct {
+	BuildID         string                  `json:"buildId"`
+	Profile         string                  `json:"profile"`
+	Status          string                  `json:"status"`
+	Version         string                  `json:"version"`
+	Timestamp       string                  `json:"timestamp"`
+	StartTime       int64                   `json:"startTime"`
+	EndTime         int64                   `json:"endTime"`
+	BuildTime       int64                   `json:"buildTime"`
+	FilesProcessed  int                     `json:"filesProcessed"`
+	CacheHits       int                     `json:"cacheHits"`
+	CacheMisses     int                     `json:"cacheMisses"`
+	CacheEfficiency int                     `json:"cacheEfficiency"`
+	BundleSizes     map[string]BundleSize   `json:"bundleSizes"`
+	Assets          AssetSummary            `json:"assets"`
+	MemoryUsage     map[string]MemorySample `json:"memoryUsage"`
+	Errors          []ReportIssue           `json:"errors"`
+	Warnings        []ReportIssue           `json:"warnings"`
+	Optimizations   []Optimization          `json:"optimizations"`
+	Steps           []StepTiming            `json:"steps"`
+	FileDetails     []FileDetail            `json:"fileDetails"`
+}
+
+// BundleSize is one tracked JS/CSS bundle
+type BundleSize struct {
+	Size int64  `json:"size"`
+	Type string `json:"type"`
+}
+
+// AssetSummary totals every file in the built assets directory
+type AssetSummary struct {
+	Count     int   `json:"count"`
+	TotalSize int64 `json:"totalSize"`
+}
+
+// MemorySample is the Node heap at one build stage
+type MemorySample struct {
+	HeapUsedMB  int   `json:"heapUsedMB"`
+	HeapTotalMB int   `json:"heapTotalMB"`
+	ExternalMB  int   `json:"externalMB"`
+	Timestamp   int64 `json:"timestamp"`
+}
+
+// ReportIssue is an error or warning recorded during the build
+type ReportIssue struct {
+	Message   string `json:"message"`
+	Timestamp int64  `json:"timestamp"`
+}
+
+// Optimization is a suggestion from the analytics, e.g. low cache efficiency
+type Optimization struct {
+	Type    string `json:"type"`
+	Impact  string `json:"impact"`
+	Message string `json:"message"`
+	Action  string `json:"action"`
+}
+
+// StepTiming is the start and end of one build step, in Unix milliseconds
+type StepTiming struct {
+	Name     string `json:"name"`
+	Start    int64  `json:"start"`
+	End      int64  `json:"end"`
+	Duration int64  `json:"duration"`
+	Status   string `json:"status"`
+}
+
+// FileDetail is the processing time of one file
+type FileDetail struct {
+	Path           string  `json:"path"`
+	ProcessingTime float64 `json:"processingTime"`
+	FromCache      bool    `json:"fromCache"`
+}
+
+// BuildAnalysis is the analytics summary stored next to the metrics
+type BuildAnalysis struct {
+	Performance struct {
+		AvgBuildTime     float64 `json:"avgBuildTime"`
+		PerformanceScore int     `json:"performanceScore"`
+		IsRegression     bool    `json:"isRegression"`
+		Recommendation   string  `json:"recommendation"`
+	} `json:"performance"`
+	Bundles struct {
+		TotalSize int64 `json:"totalSize"`
+	} `json:"bundles"`
+	Optimizations []Optimization `json:"optimizations"`
+}
+
+// Outcome is "success" or "failed"; older reports without a status count as failed when they recorded errors
+func (r BuildReport) Outcome() string {
+	if r.Metrics.Status != "" {
+		return r.Metrics.Status
+	}
+	if len(r.Metrics.Errors) > 0 {
+		return "failed"
+	}
+	return "success"
+}
+
+// Started returns when the build began
+func (r BuildReport) Started() time.Time {
+	if r.Metrics.StartTime > 0 {
+		return time.UnixMilli(r.Metrics.StartTime)
+	}
+	started, _ := time.Parse(time.RFC3339, r.Metrics.Timestamp)
+	return started
+}
+
+// analyticsDir is where build-analytics.js stores history and reports
+func analyticsDir() string {
+	return filepath.Join(projectRoot(), "analytics-data")
+}
+
+// historyEntry is a build-history.json entry: the metrics inline plus the analysis
+type historyEntry struct {
+	BuildMetrics
+	Analysis BuildAnalysis `json:"analysis"`
+}
+
+// loadBuildHistory returns every known build, newest first. It merges
+// build-history.json with report files that have aged out of the history.
+func loadBuildHistory() ([]BuildReport, error) {
+	var reports []BuildReport
+	seen := make(map[string]bool)
+
+	data, err := os.ReadFile(filepath.Join(analyticsDir(), "build-history.json"))
+	if err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("failed to read build history: %v", err)
+	}
+	if err == nil {
+		var entries []historyEntry
+		if err := json.Unmarshal(data, &entries); err != nil {
+			return nil, fmt.Errorf("failed to parse build history: %v", err)
+		}
+		for _, entry := range entries {
+			reports = append(reports, BuildReport{Metrics: entry.BuildMetrics, Analysis: entry.Analysis})
+			seen[entry.BuildID] = true
+		}
+	}
+
+	paths, _ := filepath.Glob(filepath.Join(analyticsDir(), "report-*.json"))
+	for _, path := range paths {
+		id := strings.TrimSuffix(strings.TrimPrefix(filepath.Base(path), "report-"), ".json")
+		if seen[id] {
+			continue
+		}
+		report, err := loadBuildReport(id)
+		if err != nil {
+			continue
+		}
+		reports = append(reports, report)
+	}
+
+	sort.SliceStable(reports, func(i, j int) bool {
+		return reports[i].Started().After(reports[j].Started())
+	})
+	return reports, nil
+}
+
+// loadBuildReport reads report-<id>.json
+func loadBuildReport(id string) (BuildReport, error) {
+	var report BuildReport
+	data, err := os.ReadFile(filepath.Join(analyticsDir(), fmt.Sprintf("report-%s.json", id)))
+	if err != nil {
+		return report, fmt.Errorf("failed to read report %s: %v", id, err)
+	}
+	if err := json.Unmarshal(data, &report); err != nil {
+		return report, fmt.Errorf("failed to parse report %s: %v", id, err)
+	}
+	return report, nil
+}
+
+// formatMillis renders a millisecond duration like build-analytics.js does
+func formatMillis(ms int64) string {
+	switch {
+	case ms < 1000:
+		return fmt.Sprintf("%dms", ms)
+	case ms < 60000:
+		return fmt.Sprintf("%.1fs", float64(ms)/1000)
+	default:
+		return fmt.Sprintf("%dm %ds", ms/60000, (ms%60000)/1000)
+	}
+}
+
+// formatBytes renders a byte count with a binary unit
+func formatBytes(bytes int64) string {
+	const unit = 1024
+	if bytes < unit {
+		return fmt.Sprintf("%d B", bytes)
+	}
+	value, suffix := float64(bytes)/unit, "KB"
+	for _, next := range []string{"MB", "GB"} {
+		if value < unit {
+			break
+		}
+		value, suffix = value/unit, next
+	}
+	return fmt.Sprintf("%.2f %s", value, suffix)
+}
+
+// outcomeIcon marks a build outcome in lists
+func outcomeIcon(outcome string) string {
+	if outcome == "success" {
+		return "✅"
+	}
+	return "❌"
+}
+
+// openHistory loads analytics-data and shows the History screen
+func (m Model) openHistory() Model {
+	m.state = StateHistory
+	m.historyCursor = 0
+	reports, err := loadBuildHistory()
+	m.history = reports
+	m.historyRegressions = historyRegressions(reports)
+	if err != nil {
+		m.statusMessage = fmt.Sprintf("❌ %v", err)
+	}
+	return m
+}
+
+func (m Model) handleHistoryKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "ctrl+c", "q":
+		m.backend.Cleanup()
+		return m, tea.Quit
+	case "esc":
+		m.state = StateMenu
+	case "up", "k":
+		if m.historyCursor > 0 {
+			m.historyCursor--
+		}
+	case "down", "j":
+		if m.historyCursor < len(m.history)-1 {
+			m.historyCursor++
+		}
+	case "r":
+		return m.openHistory(), nil
+	case " ":
+		// Mark a build to compare against
+		if m.historyCursor < len(m.history) {
+			id := m.history[m.historyCursor].Metrics.BuildID
+			if m.compareMark == id {
+				m.compareMark = ""
+			} else {
+				m.compareMark = id
+			}
+		}
+	case "c":
+		return m.openComparison(), nil
+	case "enter":
+		if m.historyCursor < len(m.history) {
+			// Prefer the report file; the history entry is the same data when it has been cleaned up
+			report := m.history[m.historyCursor]
+			if full, err := loadBuildReport(report.Metrics.BuildID); err == nil {
+				report = full
+			}
+			m.report = report
+			m.reportScroll = 0
+			m.state = StateReport
+		}
+	}
+	return m, nil
+}
+
+func (m Model) renderHistory() string {
+	s := "\n"
+	s += titleStyle.Render("🕘 BUILD HISTORY") + "\n\n"
+
+	if len(m.history) == 0 {
+		s += detailStyle.Render("No builds recorded yet — reports appear in analytics-data after each TUI build") + "\n"
+	} else {
+		s += statsStyle.Render(fmt.Sprintf("  %-9s %-17s %-12s %9s %6s %6s  %s", "ID", "Date", "Profile", "Duration", "Files", "Cache", "Outcome")) + "\n"
+
+		const visible = 15
+		start := 0
+		if m.historyCursor >= visible {
+			start = m.historyCursor - visible + 1
+		}
+		for i := start; i < len(m.history) && i < start+visible; i++ {
+			report := m.history[i]
+			metrics := report.Metrics
+			line := fmt.Sprintf("%-9s %-17s %-12s %9s %6d %6d  %s %s",
+				metrics.BuildID, report.Started().Format("2006-01-02 15:04"), truncate(metrics.Profile, 12),
+				formatMillis(metrics.BuildTime), metrics.FilesProcessed, metrics.CacheHits,
+				outcomeIcon(report.Outcome()), report.Outcome())
+			if _, ok := m.historyRegressions[metrics.BuildID]; ok {
+				line += " 🐢"
+			}
+			if metrics.BuildID == m.compareMark {
+				line += " ★"
+			}
+			if i == m.historyCursor {
+				s += selectedStyle.Render("> "+line) + "\n"
+			} else {
+				s += normalStyle.Render("  "+line) + "\n"
+			}
+		}
+		s += "\n" + detailStyle.Render(fmt.Sprintf("%d builds in %s", len(m.history), analyticsDir())) + "\n"
+		if len(m.historyRegressions) > 0 {
+			s += detailStyle.Render(fmt.Sprintf("🐢 %d duration regressions against the median of the previous %d builds per profile",
+				len(m.historyRegressions), regressionWindow)) + "\n"
+		}
+	}
+
+	if m.statusMessage != "" {
+		s += "\n" + infoStyle.Render(m.statusMessage) + "\n"
+	}
+
+	s += "\n" + helpStyle.Render("↑/↓: navigate • enter: open report • space: mark • c: compare with marked • r: reload • esc: return to menu") + "\n"
+	return s
+}
+
+func (m Model) handleReportKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "ctrl+c", "q":
+		m.backend.Cleanup()
+		return m, tea.Quit
+	case "esc":
+		m.state = StateHistory
+	default:
+		m.reportScroll = m.scrollLines(msg, m.reportScroll, len(reportLines(m.report)))
+	}
+	return m, nil
+}
+
+// visibleLines is how many lines of a scrolling view fit between its title and help line
+func (m Model) visibleLines() int {
+	height := m.height
+	if height == 0 {
+		height = 30
+	}
+	return max(height-8, 10)
+}
+
+// scrollLines applies a scroll key to the offset of a view with lineCount lines
+func (m Model) scrollLines(msg tea.KeyMsg, scroll, lineCount int) int {
+	switch msg.String() {
+	case "up", "k":
+		scroll--
+	case "down", "j":
+		scroll++
+	case "pgup":
+		scroll -= 10
+	case "pgdown", " ":
+		scroll += 10
+	}
+	return min(max(scroll, 0), max(lineCount-m.visibleLines(), 0))
+}
+
+// renderScrolled draws the visible window of lines with a position indicator
+func (m Model) renderScrolled(lines []string, scroll int) string {
+	visible := m.visibleLines()
+	scroll = min(scroll, max(len(lines)-visible, 0))
+	end := min(scroll+visible, len(lines))
+	s := strings.Join(lines[scroll:end], "\n") + "\n"
+	if len(lines) > visible {
+		s += "\n" + detailStyle.Render(fmt.Sprintf("lines %d-%d of %d", scroll+1, end, len(lines))) + "\n"
+	}
+	return s
+}
+
+// reportLines lays out a full report as styled lines for scrolling
+func reportLines(report BuildReport) []string {
+	metrics := report.Metrics
+	var lines []string
+	section := func(title string) {
+		lines = append(lines, "", statsStyle.Render(title))
+	}
+	detail := func(format string, args ...interface{}) {
+		lines = append(lines, detailStyle.Render("  "+fmt.Sprintf(format, args...)))
+	}
+
+	lines = append(lines, statusStyle.Render(fmt.Sprintf("%s Build %s — %s", outcomeIcon(report.Outcome()), metrics.BuildID, report.Outcome())))
+	lines = append(lines, infoStyle.Render(fmt.Sprintf("%s • profile %s • v%s",
+		report.Started().Format("2006-01-02 15:04:05"), metrics.Profile, metrics.Version)))
+
+	section("⚡ Performance:")
+	performance := report.Analysis.Performance
+	detail("Duration: %s (avg %s)", formatMillis(metrics.BuildTime), formatMillis(int64(performance.AvgBuildTime)))
+	detail("Performance score: %d/100", performance.PerformanceScore)
+	detail("Files processed: %d", metrics.FilesProcessed)
+	detail("Cache: %d hits, %d misses (%d%% efficiency)", metrics.CacheHits, metrics.CacheMisses, metrics.CacheEfficiency)
+	if performance.IsRegression {
+		lines = append(lines, errorStyle.Render("  ⚠️  Slower than the previous build"))
+	}
+	if performance.Recommendation != "" {
+		detail("%s", performance.Recommendation)
+	}
+
+	section("⏱️  Steps:")
+	if len(metrics.Steps) == 0 {
+		detail("No step timings recorded")
+	}
+	for _, step := range metrics.Steps {
+		share := 0.0
+		if metrics.BuildTime > 0 {
+			share = float64(step.Duration) / float64(metrics.BuildTime)
+		}
+		detail("%-14s %8s %s %3.0f%%  %s", step.Name, formatMillis(step.Duration), shareBar(share, 20), share*100, step.Status)
+	}
+
+	if len(metrics.BundleSizes) > 0 || metrics.Assets.Count > 0 {
+		section("📦 Bundles:")
+		if metrics.Assets.Count > 0 {
+			detail("Assets: %d files, %s", metrics.Assets.Count, formatBytes(metrics.Assets.TotalSize))
+		}
+		names := make([]string, 0, len(metrics.BundleSizes))
+		for name := range metrics.BundleSizes {
+			names = append(names, name)
+		}
+		sort.Slice(names, func(i, j int) bool {
+			return metrics.BundleSizes[names[i]].Size > metrics.BundleSizes[names[j]].Size
+		})
+		for i, name := range names {
+			if i == 10 {
+				detail("… and %d more", len(names)-10)
+				break
+			}
+			detail("%-32s %10s", truncate(name, 32), formatBytes(metrics.BundleSizes[name].Size))
+		}
+	}
+
+	if len(metrics.MemoryUsage) > 0 {
+		section("🧠 Memory:")
+		stages := make([]string, 0, len(metrics.MemoryUsage))
+		for stage := range metrics.MemoryUsage {
+			stages = append(stages, stage)
+		}
+		sort.Slice(stages, func(i, j int) bool {
+			return metrics.MemoryUsage[stages[i]].Timestamp < metrics.MemoryUsage[stages[j]].Timestamp
+		})
+		for _, stage := range stages {
+			sample := metrics.MemoryUsage[stage]
+			detail("%-10s heap %dMB / %dMB, external %dMB", stage, sample.HeapUsedMB, sample.HeapTotalMB, sample.ExternalMB)
+		}
+	}
+
+	if slowest := slowestFiles(metrics.FileDetails, 5); len(slowest) > 0 {
+		section("🐢 Slowest files:")
+		for _, file := range slowest {
+			detail("%8s  %s", formatMillis(int64(file.ProcessingTime)), file.Path)
+		}
+	}
+
+	optimizations := append(append([]Optimization(nil), report.Analysis.Optimizations...), metrics.Optimizations...)
+	if len(optimizations) > 0 {
+		section("💡 Optimization suggestions:")
+		for _, optimization := range optimizations {
+			detail("%s: %s", strings.ToUpper(optimization.Impact), optimization.Message)
+			if optimization.Action != "" {
+				detail("  → %s", optimization.Action)
+			}
+		}
+	}
+
+	if len(metrics.Errors)+len(metrics.Warnings) > 0 {
+		section("⚠️  Issues:")
+		for _, issue := range metrics.Errors {
+			lines = append(lines, errorStyle.Render("  ERROR: "+issue.Message))
+		}
+		for _, issue := range metrics.Warnings {
+			detail("WARNING: %s", issue.Message)
+		}
+	}
+
+	return lines
+}
+
+// slowestFiles returns the limit files that took longest to process
+func slowestFiles(files []FileDetail, limit int) []FileDetail {
+	sorted := append([]FileDetail(nil), files...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].ProcessingTime > sorted[j].ProcessingTime })
+	if len(sorted) > limit {
+		sorted = sorted[:limit]
+	}
+	return sorted
+}
+
+// shareBar draws a fraction between 0 and 1 as a fixed-width bar
+func shareBar(fraction float64, width int) string {
+	filled := int(fraction*float64(width) + 0.5)
+	filled = min(max(filled, 0), width)
+	return strings.Repeat("█", filled) + strings.Repeat("░", width-filled)
+}
+
+func (m Model) renderReport() string {
+	s := "\n"
+	s += titleStyle.Render("📄 BUILD REPORT") + "\n\n"
+	s += m.renderScrolled(reportLines(m.report), m.reportScroll)
+	s += "\n" + helpStyle.Render("↑/↓: scroll • pgup/pgdown: page • esc: back to history") + "\n"
+	return s
+}
@@ -0,0 +1,487 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// JobState is the lifecycle state of a backend job
+type JobState string
+
+const (
+	JobRunning   JobState = "running"
+	JobSucceeded JobState = "succeeded"
+	JobFailed    JobState = "failed"
+	JobStopped   JobState = "stopped"
+)
+
+// Icon returns the status icon used in job tabs
+func (s JobState) Icon() string {
+	switch s {
+	case JobRunning:
+		return "🔄"
+	case JobSucceeded:
+		return "✅"
+	case JobStopped:
+		return "⏹️"
+	default:
+		return "❌"
+	}
+}
+
+// Job is one named process managed by the backend, such as "build", "watch" or "script:lint"
+type Job struct {
+	ID         string    `json:"id"`
+	Kind       string    `json:"kind"`
+	Title      string    `json:"title"`
+	State      JobState  `json:"state"`
+	PID        int       `json:"pid"`
+	StartedAt  time.Time `json:"startedAt"`
+	FinishedAt time.Time `json:"finishedAt"`
+	ExitCode   int       `json:"exitCode"`
+	Logs       []string  `json:"-"`
+
+	cmd           *exec.Cmd
+	stopRequested bool
+	done          chan struct{}
+}
+
+// Duration returns how long the job ran, or has been running
+func (j Job) Duration() time.Duration {
+	if j.State == JobRunning || j.FinishedAt.IsZero() {
+		return time.Since(j.StartedAt).Round(time.Second)
+	}
+	return j.FinishedAt.Sub(j.StartedAt).Round(time.Millisecond)
+}
+
+// JobHandlers receive a job's output lines and its exit. Any of them may be nil.
+type JobHandlers struct {
+	OnStdout func(line string)
+	OnStderr func(line string)
+	OnExit   func(job Job)
+}
+
+// maxJobLogLines bounds each job's log buffer
+const maxJobLogLines = 1000
+
+// JobManager runs several named processes at once, each with its own status and log buffer
+type JobManager struct {
+	mutex sync.RWMutex
+	jobs  map[string]*Job
+	order []string
+}
+
+// NewJobManager creates an empty job manager
+func NewJobManager() *JobManager {
+	return &JobManager{jobs: make(map[string]*Job)}
+}
+
+// Start launches cmd as the job id. Stdout lines starting with TUI_DATA: go
+// only to the handler; every other line is also kept in the job's log.
+func (jm *JobManager) Start(id, kind, title string, cmd *exec.Cmd, handlers JobHandlers) error {
+	jm.mutex.Lock()
+	defer jm.mutex.Unlock()
+
+	if existing, ok := jm.jobs[id]; ok && existing.State == JobRunning {
+		return fmt.Errorf("%s is already running", title)
+	}
+
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return fmt.Errorf("failed to get stdout pipe: %v", err)
+	}
+	stderr, err := cmd.StderrPipe()
+	if err != nil {
+		return fmt.Errorf("failed to get stderr pipe: %v", err)
+	}
+
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("failed to start %s: %v", title, err)
+	}
+
+	job := &Job{
+		ID:        id,
+		Kind:      kind,
+		Title:     title,
+		State:     JobRunning,
+		PID:       cmd.Process.Pid,
+		StartedAt: time.Now(),
+		cmd:       cmd,
+		done:      make(chan struct{}),
+	}
+	if _, ok := jm.jobs[id]; !ok {
+		jm.order = append(jm.order, id)
+	}
+	jm.jobs[id] = job
+
+	var readers sync.WaitGroup
+	readers.Add(2)
+	go jm.readOutput(job, stdout, handlers.OnStdout, &readers)
+	go jm.readOutput(job, stderr, handlers.OnStderr, &readers)
+	go jm.monitor(job, &readers, handlers.OnExit)
+
+	return nil
+}
+
+func (jm *JobManager) readOutput(job *Job, pipe io.Reader, handler func(string), readers *sync.WaitGroup) {
+	defer readers.Done()
+	scanner := bufio.NewScanner(pipe)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if !strings.HasPrefix(line, "TUI_DATA:") && strings.TrimSpace(line) != "" {
+			jm.appendLog(job, ansiPattern.ReplaceAllString(line, ""))
+		}
+		if handler != nil {
+			handler(line)
+		}
+	}
+}
+
+func (jm *JobManager) monitor(job *Job, readers *sync.WaitGroup, onExit func(Job)) {
+	// Drain the pipes before Wait closes them
+	readers.Wait()
+	err := job.cmd.Wait()
+
+	jm.mutex.Lock()
+	job.FinishedAt = time.Now()
+	job.ExitCode = 0
+	if exitErr, ok := err.(*exec.ExitError); ok {
+		job.ExitCode = exitErr.ExitCode()
+	} else if err != nil {
+		job.ExitCode = -1
+	}
+	switch {
+	case job.stopRequested:
+		job.State = JobStopped
+	case job.ExitCode == 0:
+		job.State = JobSucceeded
+	default:
+		job.State = JobFailed
+	}
+	snapshot := job.snapshot()
+	jm.mutex.Unlock()
+
+	if onExit != nil {
+		onExit(snapshot)
+	}
+	close(job.done)
+}
+
+func (jm *JobManager) appendLog(job *Job, line string) {
+	jm.mutex.Lock()
+	defer jm.mutex.Unlock()
+	job.Logs = append(job.Logs, line)
+	if len(job.Logs) > maxJobLogLines {
+		job.Logs = job.Logs[len(job.Logs)-maxJobLogLines:]
+	}
+}
+
+// Log adds a line to a job's log buffer, e.g. a message decoded from TUI_DATA
+func (jm *JobManager) Log(id, line string) {
+	jm.mutex.RLock()
+	job, ok := jm.jobs[id]
+	jm.mutex.RUnlock()
+	if ok {
+		jm.appendLog(job, line)
+	}
+}
+
+// Signal sends sig to a running job's process. Only SIGTERM and SIGKILL mark
+// the job as stopped by the user.
+func (jm *JobManager) Signal(id string, sig os.Signal) error {
+	return jm.signal(id, sig, sig == syscall.SIGTERM || sig == syscall.SIGKILL)
+}
+
+// signal delivers sig to the job and, when stop is set, marks the job as
+// stopped by the user rather than failed
+func (jm *JobManager) signal(id string, sig os.Signal, stop bool) error {
+	jm.mutex.Lock()
+	defer jm.mutex.Unlock()
+
+	job, ok := jm.jobs[id]
+	if !ok || job.State != JobRunning || job.cmd.Process == nil {
+		return fmt.Errorf("no running job %q", id)
+	}
+	if stop {
+		job.stopRequested = true
+	}
+	return job.cmd.Process.Signal(sig)
+}
+
+// Stop interrupts a running job, killing it if the interrupt cannot be delivered
+func (jm *JobManager) Stop(id string) error {
+	if err := jm.signal(id, syscall.SIGINT, true); err != nil {
+		jm.mutex.Lock()
+		defer jm.mutex.Unlock()
+		job, ok := jm.jobs[id]
+		if !ok || job.State != JobRunning || job.cmd.Process == nil {
+			return err
+		}
+		if killErr := job.cmd.Process.Kill(); killErr != nil {
+			return fmt.Errorf("failed to stop %s: %v", job.Title, killErr)
+		}
+	}
+	return nil
+}
+
+// StopAll interrupts every running job
+func (jm *JobManager) StopAll() []error {
+	var errs []error
+	for _, job := range jm.List() {
+		if job.State != JobRunning {
+			continue
+		}
+		if err := jm.Stop(job.ID); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errs
+}
+
+// IsRunning reports whether the job exists and is still running
+func (jm *JobManager) IsRunning(id string) bool {
+	jm.mutex.RLock()
+	defer jm.mutex.RUnlock()
+	job, ok := jm.jobs[id]
+	return ok && job.State == JobRunning
+}
+
+// Get returns a snapshot of one job
+func (jm *JobManager) Get(id string) (Job, bool) {
+	jm.mutex.RLock()
+	defer jm.mutex.RUnlock()
+	job, ok := jm.jobs[id]
+	if !ok {
+		return Job{}, false
+	}
+	return job.snapshot(), true
+}
+
+// Wait blocks until the job has exited and its exit handler has run
+func (jm *JobManager) Wait(id string) (Job, bool) {
+	jm.mutex.RLock()
+	job, ok := jm.jobs[id]
+	jm.mutex.RUnlock()
+	if !ok {
+		return Job{}, false
+	}
+	<-job.done
+	return jm.Get(id)
+}
+
+// List returns snapshots of every job in the order they were first started
+func (jm *JobManager) List() []Job {
+	jm.mutex.RLock()
+	defer jm.mutex.RUnlock()
+	jobs := make([]Job, 0, len(jm.order))
+	for _, id := range jm.order {
+		jobs = append(jobs, jm.jobs[id].snapshot())
+	}
+	return jobs
+}
+
+// Remove forgets a finished job
+func (jm *JobManager) Remove(id string) error {
+	jm.mutex.Lock()
+	defer jm.mutex.Unlock()
+
+	job, ok := jm.jobs[id]
+	if !ok {
+		return fmt.Errorf("no job %q", id)
+	}
+	if job.State == JobRunning {
+		return fmt.Errorf("%s is still running", job.Title)
+	}
+	delete(jm.jobs, id)
+	for i, existing := range jm.order {
+		if existing == id {
+			jm.order = append(jm.order[:i], jm.order[i+1:]...)
+			break
+		}
+	}
+	return nil
+}
+
+// snapshot copies the job so callers can read it without holding the lock
+func (j *Job) snapshot() Job {
+	copied := *j
+	copied.Logs = append([]string(nil), j.Logs...)
+	return copied
+}
+
+// openJobs shows the Jobs screen
+func (m Model) openJobs() Model {
+	m.state = StateJobs
+	m.jobCursor = 0
+	m.splitJob = ""
+	return m
+}
+
+func (m Model) handleJobsKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	jobs := m.backend.GetJobs()
+	if m.jobCursor >= len(jobs) {
+		m.jobCursor = max(len(jobs)-1, 0)
+	}
+
+	switch msg.String() {
+	case "ctrl+c", "q":
+		m.backend.Cleanup()
+		return m, tea.Quit
+	case "esc":
+		m.state = StateMenu
+	case "left", "h", "shift+tab":
+		if len(jobs) > 0 {
+			m.jobCursor = (m.jobCursor + len(jobs) - 1) % len(jobs)
+		}
+	case "right", "l", "tab":
+		if len(jobs) > 0 {
+			m.jobCursor = (m.jobCursor + 1) % len(jobs)
+		}
+	case "s":
+		// Pin the selected job to the left pane, or unsplit
+		if m.splitJob != "" {
+			m.splitJob = ""
+		} else if m.jobCursor < len(jobs) {
+			m.splitJob = jobs[m.jobCursor].ID
+		}
+	case "x":
+		if m.jobCursor < len(jobs) {
+			if err := m.backend.StopJob(jobs[m.jobCursor].ID); err != nil {
+				m.statusMessage = fmt.Sprintf("❌ %v", err)
+			} else {
+				m.statusMessage = fmt.Sprintf("⏹️  Stopping %s", jobs[m.jobCursor].Title)
+			}
+		}
+	case "d":
+		if m.jobCursor < len(jobs) {
+			id := jobs[m.jobCursor].ID
+			if err := m.backend.DismissJob(id); err != nil {
+				m.statusMessage = fmt.Sprintf("❌ %v", err)
+			} else if m.splitJob == id {
+				m.splitJob = ""
+			}
+		}
+	}
+	return m, nil
+}
+
+func (m Model) renderJobs() string {
+	s := "\n"
+	s += titleStyle.Render("🧵 JOBS") + "\n\n"
+
+	jobs := m.backend.GetJobs()
+	if len(jobs) == 0 {
+		s += detailStyle.Render("No jobs yet — start a build, watch or npm script from the menu") + "\n"
+		s += "\n" + helpStyle.Render("esc: return to menu") + "\n"
+		return s
+	}
+	cursor := min(m.jobCursor, len(jobs)-1)
+
+	// Tabs
+	var tabs []string
+	for i, job := range jobs {
+		tab := fmt.Sprintf("%s %s", job.State.Icon(), job.Title)
+		if job.ID == m.splitJob {
+			tab += " 📌"
+		}
+		if i == cursor {
+			tabs = append(tabs, selectedStyle.Render("["+tab+"]"))
+		} else {
+			tabs = append(tabs, normalStyle.Render(" "+tab+" "))
+		}
+	}
+	s += strings.Join(tabs, " ") + "\n\n"
+
+	width, height := m.width, m.height
+	if width == 0 {
+		width = 100
+	}
+	if height == 0 {
+		height = 30
+	}
+	// Title, tabs, status and help take about ten lines
+	logLines := max(height-14, 5)
+
+	selected := jobs[cursor]
+	pinned, split := Job{}, false
+	if m.splitJob != "" && m.splitJob != selected.ID {
+		for _, job := range jobs {
+			if job.ID == m.splitJob {
+				pinned, split = job, true
+			}
+		}
+	}
+
+	if split {
+		paneWidth := (width - 3) / 2
+		left := renderJobPane(pinned, paneWidth, logLines)
+		right := renderJobPane(selected, paneWidth, logLines)
+		s += lipgloss.JoinHorizontal(lipgloss.Top, left, detailStyle.Render(" │ "), right) + "\n"
+	} else {
+		s += renderJobPane(selected, width, logLines) + "\n"
+	}
+
+	running := 0
+	for _, job := range jobs {
+		if job.State == JobRunning {
+			running++
+		}
+	}
+	s += "\n" + detailStyle.Render(fmt.Sprintf("%d of %d jobs running", running, len(jobs))) + "\n"
+	if m.statusMessage != "" {
+		s += infoStyle.Render(m.statusMessage) + "\n"
+	}
+
+	s += "\n" + helpStyle.Render("←/→: switch job • s: split with selected job • x: stop • d: dismiss finished • esc: back (jobs keep running)") + "\n"
+	return s
+}
+
+// renderJobPane draws one job's status header and the tail of its log
+func renderJobPane(job Job, width, lines int) string {
+	status := string(job.State)
+	if job.State != JobRunning {
+		status = fmt.Sprintf("%s (exit %d)", job.State, job.ExitCode)
+	}
+	header := []string{
+		statsStyle.Render(truncate(fmt.Sprintf("%s %s", job.State.Icon(), job.Title), width)),
+		detailStyle.Render(truncate(fmt.Sprintf("%s • PID %d • %s", status, job.PID, job.Duration()), width)),
+		"",
+	}
+
+	logs := job.Logs
+	if len(logs) > lines {
+		logs = logs[len(logs)-lines:]
+	}
+	body := make([]string, 0, lines)
+	for _, line := range logs {
+		body = append(body, normalStyle.Render(truncate(line, width)))
+	}
+	if len(logs) == 0 {
+		body = append(body, detailStyle.Render("No output yet"))
+	}
+	for len(body) < lines {
+		body = append(body, "")
+	}
+
+	return lipgloss.NewStyle().Width(width).Render(strings.Join(append(header, body...), "\n"))
+}
+
+// truncate shortens s to width runes, marking the cut with an ellipsis
+func truncate(s string, width int) string {
+	runes := []rune(strings.ReplaceAll(s, "\t", "  "))
+	if width <= 1 || len(runes) <= width {
+		return string(runes)
+	}
+	return string(runes[:width-1]) + "…"
+}
@@ -0,0 +1,449 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// defaultLighthouseURL is the Shopify CLI dev server, as in the lighthouse:* npm scripts
+const defaultLighthouseURL = "http://localhost:9292"
+
+// lighthouseFormFactors are the Lighthouse screen tabs; desktop runs use --preset=desktop
+var lighthouseFormFactors = []string{"desktop", "mobile"}
+
+// LighthouseMetrics are the scores (0-100) and key metrics of one run, named
+// like the entries store-historical-data.sh keeps for the CI dashboard
+type LighthouseMetrics struct {
+	Performance   float64 `json:"performance"`
+	Accessibility float64 `json:"accessibility"`
+	BestPractices float64 `json:"bestPractices"`
+	SEO           float64 `json:"seo"`
+	LCP           float64 `json:"lcp"` // ms
+	TBT           float64 `json:"tbt"` // ms
+	CLS           float64 `json:"cls"`
+}
+
+// LighthouseRun is one local Lighthouse run
+type LighthouseRun struct {
+	ID         string            `json:"id"`
+	URL        string            `json:"url"`
+	FormFactor string            `json:"formFactor"`
+	Date       time.Time         `json:"date"`
+	Metrics    LighthouseMetrics `json:"metrics"`
+	// Report is the full Lighthouse JSON, relative to analytics-data
+	Report string `json:"report"`
+}
+
+// LighthouseHistory is the recorded runs and baselines as last read from disk
+type LighthouseHistory struct {
+	Runs      []LighthouseRun
+	Baselines map[string]LighthouseRun
+	Err       error
+}
+
+// lighthouseReport is the part of a Lighthouse JSON result the TUI reads
+type lighthouseReport struct {
+	FinalURL   string `json:"finalDisplayedUrl"`
+	FetchTime  string `json:"fetchTime"`
+	Categories map[string]struct {
+		Score *float64 `json:"score"`
+	} `json:"categories"`
+	Audits map[string]struct {
+		NumericValue float64 `json:"numericValue"`
+	} `json:"audits"`
+	RuntimeError *struct {
+		Code    string `json:"code"`
+		Message string `json:"message"`
+	} `json:"runtimeError"`
+}
+
+// lighthouseDir holds the full JSON report of every local run
+func lighthouseDir() string {
+	return filepath.Join(analyticsDir(), "lighthouse")
+}
+
+// lighthouseBaselinesFile holds the baseline run of each form factor
+func lighthouseBaselinesFile() string {
+	return filepath.Join(analyticsDir(), "lighthouse-baselines.json")
+}
+
+// lighthouseRunsFile lists every local run, oldest first
+func lighthouseRunsFile() string {
+	return filepath.Join(analyticsDir(), "lighthouse-runs.json")
+}
+
+// parseLighthouseReport reads the scores and metrics from a Lighthouse JSON result
+func parseLighthouseReport(data []byte) (LighthouseMetrics, lighthouseReport, error) {
+	var report lighthouseReport
+	var metrics LighthouseMetrics
+	if err := json.Unmarshal(data, &report); err != nil {
+		return metrics, report, fmt.Errorf("failed to parse Lighthouse report: %v", err)
+	}
+	if report.RuntimeError != nil && report.RuntimeError.Code != "" {
+		return metrics, report, fmt.Errorf("Lighthouse failed: %s", report.RuntimeError.Message)
+	}
+
+	score := func(category string) float64 {
+		if c, ok := report.Categories[category]; ok && c.Score != nil {
+			return *c.Score * 100
+		}
+		return 0
+	}
+	metrics.Performance = score("performance")
+	metrics.Accessibility = score("accessibility")
+	metrics.BestPractices = score("best-practices")
+	metrics.SEO = score("seo")
+	metrics.LCP = report.Audits["largest-contentful-paint"].NumericValue
+	metrics.TBT = report.Audits["total-blocking-time"].NumericValue
+	metrics.CLS = report.Audits["cumulative-layout-shift"].NumericValue
+	return metrics, report, nil
+}
+
+// lighthouseURL is the page to audit: the running Shopify watch, or the default dev server
+func (b *Backend) lighthouseURL() string {
+	if url := b.GetWatchStatus().ShopifyURL; url != "" {
+		return url
+	}
+	return defaultLighthouseURL
+}
+
+func lighthouseJobID(formFactor string) string {
+	return "lighthouse:" + formFactor
+}
+
+// StartLighthouse audits the dev server as a job, writing the JSON report to analytics-data/lighthouse
+func (b *Backend) StartLighthouse(formFactor string) error {
+	if err := os.MkdirAll(lighthouseDir(), 0o755); err != nil {
+		return fmt.Errorf("failed to create %s: %v", lighthouseDir(), err)
+	}
+
+	now := time.Now()
+	id := fmt.Sprintf("%s-%s", now.Format("20060102-150405"), formFactor)
+	reportPath := filepath.Join(lighthouseDir(), id+".json")
+	url := b.lighthouseURL()
+
+	args := []string{"lighthouse", url, "--output=json", "--output-path=" + reportPath, "--quiet", "--chrome-flags=--headless=new"}
+	if formFactor == "desktop" {
+		args = append(args, "--preset=desktop")
+	}
+	var cmd *exec.Cmd
+	if runtime.GOOS == "windows" {
+		cmd = exec.Command("cmd", append([]string{"/c", "npx"}, args...)...)
+	} else {
+		cmd = exec.Command("npx", args...)
+	}
+	cmd.Dir = projectRoot()
+	cmd.Env = append(os.Environ(), "FORCE_COLOR=0")
+
+	err := b.jobs.Start(lighthouseJobID(formFactor), "lighthouse", "🚦 Lighthouse "+formFactor, cmd, JobHandlers{
+		OnExit: func(job Job) {
+			run := LighthouseRun{ID: id, URL: url, FormFactor: formFactor, Date: now, Report: filepath.Join("lighthouse", id+".json")}
+			err := b.finishLighthouse(job, &run, reportPath)
+			b.mutex.Lock()
+			defer b.mutex.Unlock()
+			if b.lighthouseErrors == nil {
+				b.lighthouseErrors = make(map[string]error)
+			}
+			b.lighthouseErrors[formFactor] = err
+			if err != nil {
+				b.jobs.Log(job.ID, err.Error())
+			}
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to start Lighthouse: %v", err)
+	}
+	return nil
+}
+
+// finishLighthouse parses the report of a finished run and records it
+func (b *Backend) finishLighthouse(job Job, run *LighthouseRun, reportPath string) error {
+	if job.State == JobStopped {
+		return fmt.Errorf("Lighthouse was stopped")
+	}
+	data, err := os.ReadFile(reportPath)
+	if err != nil {
+		return fmt.Errorf("Lighthouse exited with %d and wrote no report — is the dev server running at %s?", job.ExitCode, run.URL)
+	}
+	metrics, report, err := parseLighthouseReport(data)
+	if err != nil {
+		return err
+	}
+	run.Metrics = metrics
+	if report.FinalURL != "" {
+		run.URL = report.FinalURL
+	}
+	if fetched, err := time.Parse(time.RFC3339, report.FetchTime); err == nil {
+		run.Date = fetched.Local()
+	}
+
+	return b.recordLighthouseRun(*run)
+}
+
+// recordLighthouseRun appends a run to lighthouse-runs.json. Desktop and mobile
+// runs can finish together, so the update is serialized under the mutex.
+func (b *Backend) recordLighthouseRun(run LighthouseRun) error {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	runs, err := loadLighthouseRuns()
+	if err != nil {
+		return err
+	}
+	err = writeJSONFile(lighthouseRunsFile(), append(runs, run))
+	b.reloadLighthouse()
+	return err
+}
+
+// writeJSONFile stores v as indented JSON
+func writeJSONFile(path string, v interface{}) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode %s: %v", filepath.Base(path), err)
+	}
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return fmt.Errorf("failed to write %s: %v", filepath.Base(path), err)
+	}
+	return nil
+}
+
+// loadLighthouseRuns returns every recorded local run, oldest first
+func loadLighthouseRuns() ([]LighthouseRun, error) {
+	var runs []LighthouseRun
+	data, err := os.ReadFile(lighthouseRunsFile())
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read Lighthouse runs: %v", err)
+	}
+	if err := json.Unmarshal(data, &runs); err != nil {
+		return nil, fmt.Errorf("failed to parse Lighthouse runs: %v", err)
+	}
+	return runs, nil
+}
+
+// latestLighthouseRun returns the newest run of a form factor
+func latestLighthouseRun(runs []LighthouseRun, formFactor string) (LighthouseRun, bool) {
+	for i := len(runs) - 1; i >= 0; i-- {
+		if runs[i].FormFactor == formFactor {
+			return runs[i], true
+		}
+	}
+	return LighthouseRun{}, false
+}
+
+// loadLighthouseBaselines returns the baseline run of each form factor
+func loadLighthouseBaselines() (map[string]LighthouseRun, error) {
+	baselines := make(map[string]LighthouseRun)
+	data, err := os.ReadFile(lighthouseBaselinesFile())
+	if os.IsNotExist(err) {
+		return baselines, nil
+	}
+	if err != nil {
+		return baselines, fmt.Errorf("failed to read Lighthouse baselines: %v", err)
+	}
+	if err := json.Unmarshal(data, &baselines); err != nil {
+		return baselines, fmt.Errorf("failed to parse Lighthouse baselines: %v", err)
+	}
+	return baselines, nil
+}
+
+// saveLighthouseBaseline makes run the baseline of its form factor
+func saveLighthouseBaseline(run LighthouseRun) error {
+	baselines, err := loadLighthouseBaselines()
+	if err != nil {
+		return err
+	}
+	baselines[run.FormFactor] = run
+	return writeJSONFile(lighthouseBaselinesFile(), baselines)
+}
+
+// SaveLighthouseBaseline makes run the baseline of its form factor
+func (b *Backend) SaveLighthouseBaseline(run LighthouseRun) error {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	err := saveLighthouseBaseline(run)
+	b.reloadLighthouse()
+	return err
+}
+
+// reloadLighthouse reads the runs and baselines from disk. Caller must hold the mutex.
+func (b *Backend) reloadLighthouse() {
+	runs, err := loadLighthouseRuns()
+	baselines, baselinesErr := loadLighthouseBaselines()
+	if err == nil {
+		err = baselinesErr
+	}
+	b.lighthouse = LighthouseHistory{Runs: runs, Baselines: baselines, Err: err}
+}
+
+// ReloadLighthouse rereads the runs and baselines from disk
+func (b *Backend) ReloadLighthouse() {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	b.reloadLighthouse()
+}
+
+// GetLighthouseHistory returns the runs and baselines as last read from disk
+func (b *Backend) GetLighthouseHistory() LighthouseHistory {
+	b.mutex.RLock()
+	defer b.mutex.RUnlock()
+	return b.lighthouse
+}
+
+// GetLighthouseError returns why the last run of a form factor failed, if it did
+func (b *Backend) GetLighthouseError(formFactor string) error {
+	b.mutex.RLock()
+	defer b.mutex.RUnlock()
+	return b.lighthouseErrors[formFactor]
+}
+
+// lighthouseDeltas lists the scores and metrics of a run against its baseline
+func lighthouseDeltas(baseline, run LighthouseMetrics) []MetricDelta {
+	return []MetricDelta{
+		{Label: "Performance", Unit: unitScore, Before: baseline.Performance, After: run.Performance, HigherIsBetter: true},
+		{Label: "Accessibility", Unit: unitScore, Before: baseline.Accessibility, After: run.Accessibility, HigherIsBetter: true},
+		{Label: "Best practices", Unit: unitScore, Before: baseline.BestPractices, After: run.BestPractices, HigherIsBetter: true},
+		{Label: "SEO", Unit: unitScore, Before: baseline.SEO, After: run.SEO, HigherIsBetter: true},
+		{Label: "LCP", Unit: unitMillis, Before: baseline.LCP, After: run.LCP},
+		{Label: "TBT", Unit: unitMillis, Before: baseline.TBT, After: run.TBT},
+		{Label: "CLS", Unit: unitRatio, Before: baseline.CLS, After: run.CLS},
+	}
+}
+
+// scoreStyle colours a 0-100 score like the Lighthouse report: green from 90, orange from 50
+func scoreStyle(score float64) string {
+	label := fmt.Sprintf("%3.0f", score)
+	switch {
+	case score >= 90:
+		return selectedStyle.Render(label)
+	case score >= 50:
+		return statsStyle.Render(label)
+	}
+	return errorStyle.Render(label)
+}
+
+func (m Model) openLighthouse() Model {
+	m.state = StateLighthouse
+	m.statusMessage = ""
+	m.backend.ReloadLighthouse()
+	return m
+}
+
+func (m Model) handleLighthouseKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	formFactor := lighthouseFormFactors[m.lighthouseTab]
+	switch msg.String() {
+	case "ctrl+c", "q":
+		m.backend.Cleanup()
+		return m, tea.Quit
+	case "esc":
+		m.state = StateMenu
+	case "right", "l", "left", "h", "tab":
+		m.lighthouseTab = (m.lighthouseTab + 1) % len(lighthouseFormFactors)
+	case "r", "enter":
+		m.backend.ReloadLighthouse()
+		if err := m.backend.StartLighthouse(formFactor); err != nil {
+			m.statusMessage = fmt.Sprintf("❌ %v", err)
+		} else {
+			m.statusMessage = fmt.Sprintf("🚦 Auditing %s (%s)...", m.backend.lighthouseURL(), formFactor)
+		}
+	case "t":
+		return m.openLighthouseTrend(), nil
+	case "x":
+		if err := m.backend.StopJob(lighthouseJobID(formFactor)); err != nil {
+			m.statusMessage = fmt.Sprintf("❌ %v", err)
+		}
+	case "b":
+		history := m.backend.GetLighthouseHistory()
+		run, ok := latestLighthouseRun(history.Runs, formFactor)
+		switch {
+		case history.Err != nil:
+			m.statusMessage = fmt.Sprintf("❌ %v", history.Err)
+		case !ok:
+			m.statusMessage = fmt.Sprintf("No %s run to use as the baseline yet", formFactor)
+		default:
+			if err := m.backend.SaveLighthouseBaseline(run); err != nil {
+				m.statusMessage = fmt.Sprintf("❌ %v", err)
+			} else {
+				m.statusMessage = fmt.Sprintf("📌 Run %s is the %s baseline", run.ID, formFactor)
+			}
+		}
+	}
+	return m, nil
+}
+
+// renderLighthouse shows the runs the backend reloads whenever a job finishes
+func (m Model) renderLighthouse() string {
+	s := "\n"
+	s += titleStyle.Render("🚦 LIGHTHOUSE") + "\n\n"
+
+	tabs := ""
+	for i, name := range lighthouseFormFactors {
+		if i == m.lighthouseTab {
+			tabs += selectedStyle.Render(" " + name + " ")
+		} else {
+			tabs += normalStyle.Render(" " + name + " ")
+		}
+	}
+	s += tabs + "\n\n"
+
+	formFactor := lighthouseFormFactors[m.lighthouseTab]
+	s += infoStyle.Render("Target: "+m.backend.lighthouseURL()) + "\n"
+	if job, ok := m.backend.jobs.Get(lighthouseJobID(formFactor)); ok && job.State == JobRunning {
+		s += statusStyle.Render(fmt.Sprintf("🔄 Auditing... %s", job.Duration())) + "\n"
+	} else if err := m.backend.GetLighthouseError(formFactor); err != nil {
+		s += errorStyle.Render(fmt.Sprintf("❌ %v", err)) + "\n"
+	}
+	s += "\n"
+
+	history := m.backend.GetLighthouseHistory()
+	if history.Err != nil {
+		s += errorStyle.Render(fmt.Sprintf("❌ %v", history.Err)) + "\n"
+	}
+	baselines := history.Baselines
+	run, ok := latestLighthouseRun(history.Runs, formFactor)
+	if !ok {
+		s += detailStyle.Render(fmt.Sprintf("No %s runs yet — press r with the dev server running", formFactor)) + "\n"
+	} else {
+		s += statsStyle.Render(fmt.Sprintf("📋 Latest run %s (%s):", run.ID, run.Date.Format("2006-01-02 15:04"))) + "\n"
+		metrics := run.Metrics
+		s += fmt.Sprintf("  Performance %s  Accessibility %s  Best practices %s  SEO %s\n",
+			scoreStyle(metrics.Performance), scoreStyle(metrics.Accessibility), scoreStyle(metrics.BestPractices), scoreStyle(metrics.SEO))
+		s += detailStyle.Render(fmt.Sprintf("  LCP %s • TBT %s • CLS %.3f",
+			formatMillis(int64(metrics.LCP)), formatMillis(int64(metrics.TBT)), metrics.CLS)) + "\n"
+		s += detailStyle.Render("  Report: analytics-data/"+filepath.ToSlash(run.Report)) + "\n\n"
+
+		if baseline, ok := baselines[formFactor]; ok && baseline.ID != run.ID {
+			s += statsStyle.Render(fmt.Sprintf("📌 Against baseline %s (%s):", baseline.ID, baseline.Date.Format("2006-01-02 15:04"))) + "\n"
+			for _, d := range lighthouseDeltas(baseline.Metrics, metrics) {
+				line := fmt.Sprintf("  %-16s %8s → %-8s %s", d.Label, d.format(d.Before), d.format(d.After), d.DeltaString())
+				switch {
+				case d.Significant() && d.Regressed():
+					s += errorStyle.Render(line+"  ⚠️") + "\n"
+				case d.Significant():
+					s += selectedStyle.Render(line+"  ✅") + "\n"
+				default:
+					s += detailStyle.Render(line) + "\n"
+				}
+			}
+		} else if ok {
+			s += detailStyle.Render("📌 This run is the baseline") + "\n"
+		} else {
+			s += detailStyle.Render("No baseline yet — press b to keep this run as one") + "\n"
+		}
+	}
+
+	if m.statusMessage != "" {
+		s += "\n" + infoStyle.Render(m.statusMessage) + "\n"
+	}
+
+	s += "\n" + helpStyle.Render("←/→: desktop/mobile • r: run Lighthouse • b: save latest run as baseline • t: trends • x: stop • esc: return to menu") + "\n"
+	return s
+}
@@ -0,0 +1,298 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// lighthouseHistoryFile is where store-historical-data.sh keeps CI results
+func lighthouseHistoryFile() string {
+	return filepath.Join(projectRoot(), "performance-reports", "history", "historical-data.json")
+}
+
+// localLighthousePage is the page name local TUI runs are listed under
+const localLighthousePage = "local"
+
+// lighthouseHistoryEntry is one entry of historical-data.json
+type lighthouseHistoryEntry struct {
+	Page    string             `json:"page"`
+	Date    string             `json:"date"`
+	Desktop *LighthouseMetrics `json:"desktop"`
+	Mobile  *LighthouseMetrics `json:"mobile"`
+}
+
+// LighthouseSample is one run of one page in one form factor
+type LighthouseSample struct {
+	Date    time.Time
+	Metrics LighthouseMetrics
+}
+
+// LighthouseTrend holds every known run, by page and then by form factor, oldest first
+type LighthouseTrend struct {
+	Pages   []string
+	Samples map[string]map[string][]LighthouseSample
+}
+
+// parseHistoryDate accepts the dates the CI scripts have used
+func parseHistoryDate(date string) (time.Time, bool) {
+	for _, layout := range []string{"2006-01-02", time.RFC3339, "01022006"} {
+		if t, err := time.ParseInLocation(layout, date, time.Local); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// loadLighthouseTrend merges the CI history with the local runs
+func loadLighthouseTrend() (LighthouseTrend, error) {
+	trend := LighthouseTrend{Samples: make(map[string]map[string][]LighthouseSample)}
+	add := func(page, formFactor string, sample LighthouseSample) {
+		if trend.Samples[page] == nil {
+			trend.Samples[page] = make(map[string][]LighthouseSample)
+		}
+		trend.Samples[page][formFactor] = append(trend.Samples[page][formFactor], sample)
+	}
+
+	var errs []string
+	data, err := os.ReadFile(lighthouseHistoryFile())
+	if err != nil && !os.IsNotExist(err) {
+		errs = append(errs, fmt.Sprintf("failed to read CI history: %v", err))
+	}
+	if err == nil {
+		var history struct {
+			Data []lighthouseHistoryEntry `json:"data"`
+		}
+		if err := json.Unmarshal(data, &history); err != nil {
+			errs = append(errs, fmt.Sprintf("failed to parse CI history: %v", err))
+		}
+		for _, entry := range history.Data {
+			date, ok := parseHistoryDate(entry.Date)
+			if !ok {
+				continue
+			}
+			if entry.Desktop != nil {
+				add(entry.Page, "desktop", LighthouseSample{Date: date, Metrics: *entry.Desktop})
+			}
+			if entry.Mobile != nil {
+				add(entry.Page, "mobile", LighthouseSample{Date: date, Metrics: *entry.Mobile})
+			}
+		}
+	}
+
+	runs, err := loadLighthouseRuns()
+	if err != nil {
+		errs = append(errs, err.Error())
+	}
+	for _, run := range runs {
+		add(localLighthousePage, run.FormFactor, LighthouseSample{Date: run.Date, Metrics: run.Metrics})
+	}
+
+	for page, byFormFactor := range trend.Samples {
+		trend.Pages = append(trend.Pages, page)
+		for _, samples := range byFormFactor {
+			sort.SliceStable(samples, func(i, j int) bool { return samples[i].Date.Before(samples[j].Date) })
+		}
+	}
+	// Local runs first, then CI pages by name
+	sort.Slice(trend.Pages, func(i, j int) bool {
+		if (trend.Pages[i] == localLighthousePage) != (trend.Pages[j] == localLighthousePage) {
+			return trend.Pages[i] == localLighthousePage
+		}
+		return trend.Pages[i] < trend.Pages[j]
+	})
+
+	if len(errs) > 0 {
+		return trend, fmt.Errorf("%s", strings.Join(errs, "; "))
+	}
+	return trend, nil
+}
+
+// trendMetric is one metric charted over time
+type trendMetric struct {
+	Label          string
+	Unit           string
+	HigherIsBetter bool
+	Value          func(LighthouseMetrics) float64
+}
+
+var trendMetrics = []trendMetric{
+	{"Performance", unitScore, true, func(m LighthouseMetrics) float64 { return m.Performance }},
+	{"Accessibility", unitScore, true, func(m LighthouseMetrics) float64 { return m.Accessibility }},
+	{"Best practices", unitScore, true, func(m LighthouseMetrics) float64 { return m.BestPractices }},
+	{"SEO", unitScore, true, func(m LighthouseMetrics) float64 { return m.SEO }},
+	{"LCP", unitMillis, false, func(m LighthouseMetrics) float64 { return m.LCP }},
+	{"TBT", unitMillis, false, func(m LighthouseMetrics) float64 { return m.TBT }},
+	{"CLS", unitRatio, false, func(m LighthouseMetrics) float64 { return m.CLS }},
+}
+
+// delta compares a metric between two consecutive runs
+func (t trendMetric) delta(before, after LighthouseMetrics) MetricDelta {
+	return MetricDelta{Label: t.Label, Unit: t.Unit, Before: t.Value(before), After: t.Value(after), HigherIsBetter: t.HigherIsBetter}
+}
+
+// worsened lists the metrics that got significantly worse since the previous run
+func worsened(previous, current LighthouseMetrics) []string {
+	var labels []string
+	for _, metric := range trendMetrics {
+		if d := metric.delta(previous, current); d.Significant() && d.Regressed() {
+			labels = append(labels, metric.Label)
+		}
+	}
+	return labels
+}
+
+// valueSparkline draws the last width values scaled between their minimum and maximum
+func valueSparkline(values []float64, width int) string {
+	blocks := []rune("▁▂▃▄▅▆▇█")
+	if len(values) > width {
+		values = values[len(values)-width:]
+	}
+	if len(values) == 0 {
+		return ""
+	}
+	low, high := values[0], values[0]
+	for _, value := range values {
+		low, high = math.Min(low, value), math.Max(high, value)
+	}
+
+	var line strings.Builder
+	for _, value := range values {
+		level := len(blocks) / 2
+		if high > low {
+			level = int((value - low) / (high - low) * float64(len(blocks)-1))
+		}
+		line.WriteRune(blocks[level])
+	}
+	return line.String()
+}
+
+func (m Model) openLighthouseTrend() Model {
+	m.state = StateLighthouseTrend
+	m.trendScroll = 0
+	trend, err := loadLighthouseTrend()
+	m.lighthouseTrend = trend
+	m.trendPage = min(m.trendPage, max(len(trend.Pages)-1, 0))
+	if err != nil {
+		m.statusMessage = fmt.Sprintf("❌ %v", err)
+	} else {
+		m.statusMessage = ""
+	}
+	return m
+}
+
+func (m Model) handleLighthouseTrendKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "ctrl+c", "q":
+		m.backend.Cleanup()
+		return m, tea.Quit
+	case "esc":
+		m.state = StateLighthouse
+		m.statusMessage = ""
+	case "right", "l", "left", "h", "tab":
+		m.lighthouseTab = (m.lighthouseTab + 1) % len(lighthouseFormFactors)
+		m.trendScroll = 0
+	case "p":
+		if pages := len(m.lighthouseTrend.Pages); pages > 0 {
+			m.trendPage = (m.trendPage + 1) % pages
+			m.trendScroll = 0
+		}
+	case "r":
+		return m.openLighthouseTrend(), nil
+	default:
+		m.trendScroll = m.scrollLines(msg, m.trendScroll, len(m.lighthouseTrendLines()))
+	}
+	return m, nil
+}
+
+// lighthouseTrendLines lays out the charts and the run table of the selected page and form factor
+func (m Model) lighthouseTrendLines() []string {
+	trend := m.lighthouseTrend
+	if len(trend.Pages) == 0 {
+		return []string{detailStyle.Render("No Lighthouse history — run Lighthouse here or copy performance-reports/history from CI")}
+	}
+	page := trend.Pages[m.trendPage]
+	formFactor := lighthouseFormFactors[m.lighthouseTab]
+	samples := trend.Samples[page][formFactor]
+	if len(samples) == 0 {
+		return []string{detailStyle.Render(fmt.Sprintf("No %s runs for %s", formFactor, page))}
+	}
+
+	chartWidth := 30
+	if m.width > 0 {
+		chartWidth = min(max(m.width-60, 10), 60)
+	}
+	first, last := samples[0], samples[len(samples)-1]
+	lines := []string{statsStyle.Render(fmt.Sprintf("📈 %d runs, %s to %s:", len(samples),
+		first.Date.Format("2006-01-02"), last.Date.Format("2006-01-02")))}
+	for _, metric := range trendMetrics {
+		values := make([]float64, len(samples))
+		for i, sample := range samples {
+			values[i] = metric.Value(sample.Metrics)
+		}
+		overall := metric.delta(first.Metrics, last.Metrics)
+		line := fmt.Sprintf("  %-15s %s %8s  %s", metric.Label, padChart(valueSparkline(values, chartWidth), chartWidth),
+			overall.format(overall.After), overall.DeltaString())
+		switch {
+		case overall.Significant() && overall.Regressed():
+			lines = append(lines, errorStyle.Render(line))
+		case overall.Significant():
+			lines = append(lines, selectedStyle.Render(line))
+		default:
+			lines = append(lines, detailStyle.Render(line))
+		}
+	}
+
+	lines = append(lines, "", statsStyle.Render("🗓️  Runs, newest first (⚠️ = worse than the run before):"))
+	lines = append(lines, detailStyle.Render(fmt.Sprintf("  %-16s %5s %5s %5s %5s %8s %8s %7s", "Date", "Perf", "A11y", "BP", "SEO", "LCP", "TBT", "CLS")))
+	for i := len(samples) - 1; i >= 0; i-- {
+		sample := samples[i]
+		metrics := sample.Metrics
+		line := fmt.Sprintf("  %-16s %5.0f %5.0f %5.0f %5.0f %8s %8s %7.3f", sample.Date.Format("2006-01-02 15:04"),
+			metrics.Performance, metrics.Accessibility, metrics.BestPractices, metrics.SEO,
+			formatMillis(int64(metrics.LCP)), formatMillis(int64(metrics.TBT)), metrics.CLS)
+		if i == 0 {
+			lines = append(lines, normalStyle.Render(line))
+			continue
+		}
+		if labels := worsened(samples[i-1].Metrics, metrics); len(labels) > 0 {
+			lines = append(lines, errorStyle.Render(line+"  ⚠️ "+strings.Join(labels, ", ")))
+		} else {
+			lines = append(lines, normalStyle.Render(line))
+		}
+	}
+	return lines
+}
+
+func (m Model) renderLighthouseTrend() string {
+	s := "\n"
+	s += titleStyle.Render("📈 LIGHTHOUSE TRENDS") + "\n\n"
+
+	trend := m.lighthouseTrend
+	if len(trend.Pages) > 0 {
+		s += infoStyle.Render(fmt.Sprintf("Page: %s (%d/%d)", trend.Pages[m.trendPage], m.trendPage+1, len(trend.Pages))) + "  "
+	}
+	for i, name := range lighthouseFormFactors {
+		if i == m.lighthouseTab {
+			s += selectedStyle.Render(" " + name + " ")
+		} else {
+			s += normalStyle.Render(" " + name + " ")
+		}
+	}
+	s += "\n\n"
+	s += m.renderScrolled(m.lighthouseTrendLines(), m.trendScroll)
+
+	if m.statusMessage != "" {
+		s += "\n" + infoStyle.Render(m.statusMessage) + "\n"
+	}
+
+	s += "\n" + helpStyle.Render("←/→: desktop/mobile • p: next page • ↑/↓: scroll • r: reload • esc: back to Lighthouse") + "\n"
+	return s
+}
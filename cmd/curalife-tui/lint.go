@@ -0,0 +1,435 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// defaultLintDebounce is how long a file has to stay unchanged before it is linted
+	defaultLintDebounce = 750 * time.Millisecond
+	// How long and how often Theme Check waits for the watch to copy a changed file
+	builtCopyTimeout = 10 * time.Second
+	builtCopyPoll    = 250 * time.Millisecond
+)
+
+// lintDebounce returns the configured per-file debounce
+func (s Settings) lintDebounce() time.Duration {
+	if s.LintDebounce <= 0 {
+		return defaultLintDebounce
+	}
+	return time.Duration(s.LintDebounce) * time.Millisecond
+}
+
+// LintStatus is the live lint state shown on the watch screen
+type LintStatus struct {
+	Errors   int
+	Warnings int
+	Files    int // files with problems
+	Linted   int // files linted since the watch started
+	Pending  int // files waiting for their debounce or the running lint
+	Running  bool
+	// Failures holds the last error of a linter that couldn't run, by tool
+	Failures map[string]string
+}
+
+// ChangeLinter lints the files named in watch change events: ESLint for
+// JavaScript and Liquid, Theme Check for Liquid. Changes are debounced per
+// file and one worker lints whatever is due, so runs never overlap.
+type ChangeLinter struct {
+	mutex    sync.Mutex
+	delay    time.Duration
+	timers   map[string]*time.Timer // debouncing files, by project-relative path
+	due      map[string]bool
+	wake     chan struct{}
+	running  bool
+	linted   map[string]bool
+	problems map[string][]Diagnostic // only files that still have problems
+	failures map[string]string
+	// onResult receives each linted file with its current problems
+	onResult func(file string, diagnostics []Diagnostic)
+}
+
+// NewChangeLinter starts the lint worker; it exits when stop is closed
+func NewChangeLinter(delay time.Duration, stop <-chan struct{}, onResult func(string, []Diagnostic)) *ChangeLinter {
+	l := &ChangeLinter{
+		delay:    delay,
+		timers:   make(map[string]*time.Timer),
+		due:      make(map[string]bool),
+		wake:     make(chan struct{}, 1),
+		linted:   make(map[string]bool),
+		problems: make(map[string][]Diagnostic),
+		failures: make(map[string]string),
+		onResult: onResult,
+	}
+	go l.run(stop)
+	return l
+}
+
+// Schedule queues a changed file for linting once it has been quiet for the
+// debounce delay. Names that don't resolve to a JS or Liquid file in src are ignored.
+func (l *ChangeLinter) Schedule(name string) {
+	file, ok := lintTarget(name)
+	if !ok {
+		return
+	}
+
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	if timer, ok := l.timers[file]; ok {
+		timer.Stop()
+	}
+	l.timers[file] = time.AfterFunc(l.delay, func() {
+		l.mutex.Lock()
+		delete(l.timers, file)
+		l.due[file] = true
+		l.mutex.Unlock()
+		select {
+		case l.wake <- struct{}{}:
+		default:
+		}
+	})
+}
+
+// Reset forgets every pending file and problem, e.g. when a new watch starts
+func (l *ChangeLinter) Reset() {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	for _, timer := range l.timers {
+		timer.Stop()
+	}
+	l.timers = make(map[string]*time.Timer)
+	l.due = make(map[string]bool)
+	l.linted = make(map[string]bool)
+	l.problems = make(map[string][]Diagnostic)
+	l.failures = make(map[string]string)
+}
+
+// Status summarizes the problems in the linted files
+func (l *ChangeLinter) Status() LintStatus {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	status := LintStatus{
+		Files:    len(l.problems),
+		Linted:   len(l.linted),
+		Pending:  len(l.timers) + len(l.due),
+		Running:  l.running,
+		Failures: make(map[string]string, len(l.failures)),
+	}
+	for _, diagnostics := range l.problems {
+		errors, warnings := countBySeverity(diagnostics)
+		status.Errors += errors
+		status.Warnings += warnings
+	}
+	for tool, failure := range l.failures {
+		status.Failures[tool] = failure
+	}
+	return status
+}
+
+func (l *ChangeLinter) run(stop <-chan struct{}) {
+	for {
+		select {
+		case <-stop:
+			return
+		case <-l.wake:
+		}
+
+		l.mutex.Lock()
+		files := make([]string, 0, len(l.due))
+		for file := range l.due {
+			files = append(files, file)
+		}
+		l.due = make(map[string]bool)
+		l.running = len(files) > 0
+		l.mutex.Unlock()
+		if len(files) == 0 {
+			continue
+		}
+		sort.Strings(files)
+
+		results, failures := lintFiles(files)
+
+		l.mutex.Lock()
+		for _, file := range files {
+			l.linted[file] = true
+			if len(results[file]) > 0 {
+				l.problems[file] = results[file]
+			} else {
+				delete(l.problems, file)
+			}
+		}
+		for _, tool := range []string{"eslint", "theme-check"} {
+			if failure, ok := failures[tool]; ok {
+				l.failures[tool] = failure
+			} else {
+				delete(l.failures, tool)
+			}
+		}
+		l.running = false
+		l.mutex.Unlock()
+
+		for _, file := range files {
+			l.onResult(file, results[file])
+		}
+	}
+}
+
+// lintTarget resolves a changed file name, which the watch adapters report
+// as a base name, to a project-relative path under src
+func lintTarget(name string) (string, bool) {
+	extension := filepath.Ext(name)
+	if extension != ".js" && extension != ".liquid" {
+		return "", false
+	}
+	ref, err := resolveFileRef(FileRef{Path: name})
+	if err != nil {
+		return "", false
+	}
+	relative, err := filepath.Rel(projectRoot(), ref.Path)
+	if err != nil || !strings.HasPrefix(filepath.ToSlash(relative), "src/") {
+		return "", false
+	}
+	return filepath.ToSlash(relative), true
+}
+
+// lintFiles runs ESLint on every file, Theme Check for the Liquid ones and the
+// schema validator for sections, returning problems by file and the error of
+// each linter that failed to run
+func lintFiles(files []string) (map[string][]Diagnostic, map[string]string) {
+	results := make(map[string][]Diagnostic)
+	failures := make(map[string]string)
+
+	diagnostics, err := runESLint(files)
+	if err != nil {
+		failures["eslint"] = err.Error()
+	}
+	for _, d := range diagnostics {
+		results[d.File] = append(results[d.File], d)
+	}
+
+	var liquid []string
+	blockFiles := themeBlockFiles()
+	for _, file := range files {
+		if strings.HasSuffix(file, ".liquid") {
+			liquid = append(liquid, file)
+		}
+		if isSectionFile(file) {
+			if data, err := os.ReadFile(filepath.Join(projectRoot(), file)); err == nil {
+				results[file] = append(results[file], validateSectionSchema(file, string(data), blockFiles)...)
+			}
+		}
+	}
+	if len(liquid) > 0 {
+		diagnostics, err := runThemeCheckFor(liquid)
+		if err != nil {
+			failures["theme-check"] = err.Error()
+		}
+		for _, d := range diagnostics {
+			results[d.File] = append(results[d.File], d)
+		}
+	}
+	return results, failures
+}
+
+// eslintResult is one file in ESLint's JSON formatter output
+type eslintResult struct {
+	FilePath string `json:"filePath"`
+	Messages []struct {
+		RuleID   string `json:"ruleId"`
+		Severity int    `json:"severity"`
+		Message  string `json:"message"`
+		Line     int    `json:"line"`
+		Column   int    `json:"column"`
+	} `json:"messages"`
+}
+
+// runESLint lints files with the config in linting/, like linting/lint.js does
+func runESLint(files []string) ([]Diagnostic, error) {
+	args := append([]string{"eslint", "--config", "linting/.eslintrc.js", "--format", "json"}, files...)
+	var cmd *exec.Cmd
+	if runtime.GOOS == "windows" {
+		cmd = exec.Command("cmd", append([]string{"/c", "npx"}, args...)...)
+	} else {
+		cmd = exec.Command("npx", args...)
+	}
+	cmd.Dir = projectRoot()
+	cmd.Env = append(os.Environ(), "FORCE_COLOR=0")
+
+	// ESLint exits with 1 when it finds problems and 2 when it couldn't lint
+	output, err := cmd.Output()
+	if exitErr, ok := err.(*exec.ExitError); err != nil && !(ok && exitErr.ExitCode() == 1) {
+		return nil, fmt.Errorf("eslint: %v", commandError(err))
+	}
+	var report []eslintResult
+	if err := json.Unmarshal(output, &report); err != nil {
+		return nil, fmt.Errorf("eslint: failed to parse output: %v", err)
+	}
+
+	root, _ := filepath.Abs(projectRoot())
+	var diagnostics []Diagnostic
+	for _, result := range report {
+		file := result.FilePath
+		if relative, err := filepath.Rel(root, file); err == nil {
+			file = filepath.ToSlash(relative)
+		}
+		for _, message := range result.Messages {
+			severity := "warning"
+			if message.Severity == 2 {
+				severity = "error"
+			}
+			diagnostics = append(diagnostics, Diagnostic{
+				Severity: severity,
+				Tool:     "eslint",
+				Check:    message.RuleID,
+				File:     file,
+				Line:     message.Line,
+				Column:   message.Column,
+				Message:  message.Message,
+			})
+		}
+	}
+	return diagnostics, nil
+}
+
+// awaitBuiltCopies waits until the watch has copied each src file into the
+// build, so Theme Check doesn't report on the previous version. It returns the
+// files whose built copy matches and the ones still stale after the timeout.
+func awaitBuiltCopies(files []string, sources map[string]string) (current, stale []string) {
+	builtCopies := make(map[string]string, len(sources))
+	for built, source := range sources {
+		builtCopies[source] = built
+	}
+
+	root := projectRoot()
+	deadline := time.Now().Add(builtCopyTimeout)
+	pending := files
+	for {
+		var waiting []string
+		for _, file := range pending {
+			source, err := os.ReadFile(filepath.Join(root, file))
+			if err != nil {
+				continue
+			}
+			built, err := os.ReadFile(filepath.Join(root, buildDirName, builtCopies[file]))
+			if err == nil && bytes.Equal(source, built) {
+				current = append(current, file)
+			} else {
+				waiting = append(waiting, file)
+			}
+		}
+		if len(waiting) == 0 || time.Now().After(deadline) {
+			return current, waiting
+		}
+		pending = waiting
+		time.Sleep(builtCopyPoll)
+	}
+}
+
+// runThemeCheckFor checks the built theme and keeps the offenses in the given
+// src files. The CLI only checks whole themes, and cross-file checks need them anyway.
+func runThemeCheckFor(files []string) ([]Diagnostic, error) {
+	if _, err := os.Stat(filepath.Join(projectRoot(), buildDirName)); err != nil {
+		return nil, fmt.Errorf("theme-check: %s not found", buildDirName)
+	}
+	sources := sourceIndex()
+	files, stale := awaitBuiltCopies(files, sources)
+	var staleErr error
+	if len(stale) > 0 {
+		staleErr = fmt.Errorf("theme-check: skipped %s, the watch hasn't copied it to %s", strings.Join(stale, ", "), buildDirName)
+	}
+	if len(files) == 0 {
+		return nil, staleErr
+	}
+
+	output, err := themeCheckCommand().Output()
+	if _, ok := err.(*exec.ExitError); err != nil && !ok {
+		return nil, fmt.Errorf("theme-check: %v", err)
+	}
+	offenses, _, parseErr := parseThemeCheck(string(output), sources)
+	if parseErr != nil {
+		if err != nil {
+			parseErr = commandError(err)
+		}
+		return nil, fmt.Errorf("theme-check: %v", parseErr)
+	}
+
+	wanted := make(map[string]bool, len(files))
+	for _, file := range files {
+		wanted[file] = true
+	}
+	var diagnostics []Diagnostic
+	for _, offense := range offenses {
+		if wanted[offense.File] {
+			diagnostics = append(diagnostics, offense.Diagnostic)
+		}
+	}
+	return diagnostics, staleErr
+}
+
+// commandError prefers the first line a failed command wrote to stderr over its exit status
+func commandError(err error) error {
+	if exitErr, ok := err.(*exec.ExitError); ok {
+		if line, _, _ := strings.Cut(strings.TrimSpace(string(exitErr.Stderr)), "\n"); line != "" {
+			return fmt.Errorf("%s", line)
+		}
+	}
+	return err
+}
+
+// lintResult replaces a file's ESLint, Theme Check and schema diagnostics with the latest lint
+func (b *Backend) lintResult(file string, diagnostics []Diagnostic) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	kept := b.diagnostics[:0]
+	for _, d := range b.diagnostics {
+		if d.File != file || (d.Tool != "eslint" && d.Tool != "theme-check" && d.Tool != "schema") {
+			kept = append(kept, d)
+		}
+	}
+	b.diagnostics = kept
+	b.addDiagnostics(diagnostics)
+}
+
+// GetLintStatus returns the live lint state of the files changed during the watch
+func (b *Backend) GetLintStatus() LintStatus {
+	return b.linter.Status()
+}
+
+// renderLint shows the live problem count of the changed files on the watch screen
+func (m Model) renderLint() string {
+	status := m.backend.GetLintStatus()
+	if status.Linted == 0 && status.Pending == 0 && len(status.Failures) == 0 {
+		return ""
+	}
+
+	s := "\n" + statsStyle.Render("🧹 Lint on change:") + "\n"
+	activity := ""
+	if status.Running || status.Pending > 0 {
+		activity = fmt.Sprintf(" • linting %d file(s)...", max(status.Pending, 1))
+	}
+	if status.Files == 0 {
+		s += statusStyle.Render(fmt.Sprintf("  ✅ No problems in %d changed file(s)%s", status.Linted, activity)) + "\n"
+	} else {
+		s += errorStyle.Render(fmt.Sprintf("  %d errors, %d warnings in %d file(s)%s (see Diagnostics)",
+			status.Errors, status.Warnings, status.Files, activity)) + "\n"
+	}
+	tools := make([]string, 0, len(status.Failures))
+	for tool := range status.Failures {
+		tools = append(tools, tool)
+	}
+	sort.Strings(tools)
+	for _, tool := range tools {
+		s += detailStyle.Render("  ⚠️  "+truncate(status.Failures[tool], 100)) + "\n"
+	}
+	return s
+}
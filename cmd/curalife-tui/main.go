@@ -47,6 +47,24 @@ const (
 	StateMenu AppState = iota
 	StateBuild
 	StateWatch
+	StateDiagnostics
+	StateBuildOptions
+	StateScripts
+	StateJobs
+	StateHistory
+	StateReport
+	StateCompare
+	StateAnalytics
+	StateProcesses
+	StateCache
+	StateThemeCheck
+	StateLighthouse
+	StateLighthouseTrend
+	StateUnused
+	StateGraph
+	StateParams
+	StateOutputChanges
+	StateFileDiff
 )
 
 // Model represents the application state
@@ -58,6 +76,83 @@ type Model struct {
 	lastUpdate time.Time
 	ctx        context.Context
 	cancel     context.CancelFunc
+	// Selected entry in the watch/build feed
+	feedCursor int
+	// Selected entry in the diagnostics panel
+	diagCursor int
+	// Show a QR code of the preview URL in watch mode
+	showQR bool
+	// Pre-build options form
+	buildOptions  BuildOptions
+	profiles      []BuildProfile
+	profileIndex  int
+	optionsCursor int
+	namingProfile bool
+	profileName   string
+	// npm script runner
+	scriptGroups     []ScriptGroup
+	scriptCursor     int
+	scriptQuery      string
+	searchingScripts bool
+	// Jobs screen: selected tab and the job pinned to the left split pane
+	jobCursor int
+	splitJob  string
+	// Build history browser and the report opened from it
+	history       []BuildReport
+	historyCursor int
+	report        BuildReport
+	reportScroll  int
+	// Comparison of two history entries; compareMark is the marked build ID
+	compareMark   string
+	comparison    ReportComparison
+	compareScroll int
+	// Builds flagged as duration regressions, by build ID
+	historyRegressions map[string]RegressionResult
+	// PID selected on the Processes screen
+	processPID int
+	// Build cache inspection, which runs in the background; wiping needs a second key press
+	cache          CacheInspection
+	cacheLoading   bool
+	cacheScroll    int
+	cacheWipeArmed bool
+	// Theme Check offenses browser: selected offense and grouping
+	checkCursor   int
+	checkGrouping int
+	// Lighthouse screen tab: desktop or mobile
+	lighthouseTab int
+	// Lighthouse history, the page it is shown for and its scroll offset
+	lighthouseTrend LighthouseTrend
+	trendPage       int
+	trendScroll     int
+	// Unused files screen: tab, selection, files marked for moving and the move confirmation
+	unused          UnusedReport
+	unusedTab       int
+	unusedCursor    int
+	unusedMarked    map[string]bool
+	unusedMoveArmed bool
+	// Dependency graph screen: selected file, search and tree direction
+	graph          *ThemeGraph
+	graphCursor    int
+	graphQuery     string
+	searchingGraph bool
+	graphUses      bool
+	// Snippet parameter contracts, the selected one and whether clean ones are listed
+	contracts     []SnippetContract
+	paramsCursor  int
+	paramsShowAll bool
+	// Build output changes, the selected one and the diff opened from it
+	outputDiff     OutputDiff
+	outputCursor   int
+	fileDiff       []string
+	fileDiffScroll int
+	// Analytics dashboard data and selected tab
+	analytics    AnalyticsData
+	analyticsTab int
+	// Terminal size from the last WindowSizeMsg
+	width  int
+	height int
+	// One-line status shown under the current screen (editor errors etc.)
+	statusMessage string
 }
 
 // Messages for handling async operations
@@ -101,10 +196,17 @@ type ProcessOutputMsg struct {
 	Level  string
 }
 
+// ProcessInfo is one process in the tree under a backend job
 type ProcessInfo struct {
-	PID     int
-	Command string
-	Status  string
+	PID       int
+	PPID      int
+	Job       string // ID of the job the process belongs to
+	Command   string
+	Status    string
+	CPU       float64 // percent of one core
+	RSS       int64   // bytes
+	OpenFiles int
+	StartedAt time.Time
 }
 
 // AnalyticsData represents analytics information
@@ -133,6 +235,8 @@ type PerformanceData struct {
 	OptimizationTime time.Duration `json:"optimization_time"`
 }
 
+// SystemData aggregates the process trees of the running jobs: CPU in percent
+// of one core, memory as RSS in MB, disk as I/O in MB/s and the 1-minute load
 type SystemData struct {
 	CPUUsage      float64 `json:"cpu_usage"`
 	MemoryUsage   float64 `json:"memory_usage"`
@@ -165,21 +269,57 @@ type Settings struct {
 	EnableSound      bool   `json:"enable_sound"`
 	AutoRefresh      bool   `json:"auto_refresh"`
 	RefreshInterval  int    `json:"refresh_interval"`
+	SampleInterval   int    `json:"sample_interval_ms"`
+	MemoryPolicy     string `json:"memory_policy"`
+	MemoryThreshold  int    `json:"memory_threshold_mb"`
+	MemorySustain    int    `json:"memory_sustain_s"`
+	LintOnChange     bool   `json:"lint_on_change"`
+	LintDebounce     int    `json:"lint_debounce_ms"`
 	MaxLogEntries    int    `json:"max_log_entries"`
 	DefaultMode      string `json:"default_mode"`
 }
 
+const settingsFile = "settings.json"
+
+// loadSettings reads settings.json from the config directory over the defaults
+func loadSettings() Settings {
+	settings := Settings{
+		SampleInterval:  int(defaultSampleInterval / time.Millisecond),
+		MemoryPolicy:    MemoryPolicyNotify,
+		MemoryThreshold: defaultMemoryThresholdMB,
+		MemorySustain:   int(defaultMemorySustain / time.Second),
+		LintOnChange:    true,
+		LintDebounce:    int(defaultLintDebounce / time.Millisecond),
+	}
+	loadConfigFile(settingsFile, &settings)
+	return settings
+}
+
 // Initialize the model
-func initialModel() Model {
+func initialModel(backend *Backend) Model {
 	return Model{
 		state:   StateMenu,
 		cursor:  0,
-		backend: NewBackend(),
+		backend: backend,
 		menuItems: []string{
 			"🔨 Build Theme",
 			"👁️  Watch Mode",
 			"📊 Build with Report",
+			"⚙️  Build with Options...",
 			"🛍️  Shopify Watch",
+			"📜 NPM Scripts",
+			"🧵 Jobs",
+			"🌳 Processes",
+			"🕘 Build History",
+			"📊 Analytics",
+			"🗄️  Build Cache",
+			"🔍 Theme Check",
+			"🚦 Lighthouse",
+			"🗑️  Unused Files",
+			"🕸️  Dependency Graph",
+			"📐 Snippet Parameters",
+			"📦 Build Output Changes",
+			"🩺 Diagnostics",
 			"❌ Exit",
 		},
 		lastUpdate: time.Now(),
@@ -197,6 +337,9 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
 		return m.handleKeyPress(msg)
+	case tea.WindowSizeMsg:
+		m.width, m.height = msg.Width, msg.Height
+		return m, nil
 	case TickMsg:
 		m.lastUpdate = time.Time(msg)
 		return m, tea.Tick(time.Second, func(t time.Time) tea.Msg {
@@ -208,6 +351,33 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m, nil
 	case WatchUpdateMsg:
 		return m, nil
+	case editorResolvedMsg:
+		return m, runEditor(msg.Ref)
+	case EditorFinishedMsg:
+		if msg.Err != nil {
+			m.statusMessage = fmt.Sprintf("❌ %s: %v", msg.Ref, msg.Err)
+		} else {
+			m.statusMessage = fmt.Sprintf("✏️  Edited %s", msg.Ref)
+		}
+		return m, nil
+	case schemasValidatedMsg:
+		if msg.Count > 0 {
+			m.statusMessage = fmt.Sprintf("🧩 %d section schema problem(s)", msg.Count)
+		} else {
+			m.statusMessage = "✅ Section schemas are valid"
+		}
+		return m, nil
+	case cacheInspectedMsg:
+		return m.showCache(msg), nil
+	case clipboardMsg:
+		return m, copyToClipboard(msg.Text, msg.Status)
+	case URLActionMsg:
+		if msg.Err != nil {
+			m.statusMessage = fmt.Sprintf("❌ %v", msg.Err)
+		} else {
+			m.statusMessage = msg.Status
+		}
+		return m, nil
 	}
 
 	return m, nil
@@ -221,6 +391,42 @@ func (m Model) handleKeyPress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		return m.handleBuildKeys(msg)
 	case StateWatch:
 		return m.handleWatchKeys(msg)
+	case StateDiagnostics:
+		return m.handleDiagnosticsKeys(msg)
+	case StateBuildOptions:
+		return m.handleBuildOptionsKeys(msg)
+	case StateScripts:
+		return m.handleScriptsKeys(msg)
+	case StateJobs:
+		return m.handleJobsKeys(msg)
+	case StateHistory:
+		return m.handleHistoryKeys(msg)
+	case StateReport:
+		return m.handleReportKeys(msg)
+	case StateCompare:
+		return m.handleCompareKeys(msg)
+	case StateAnalytics:
+		return m.handleAnalyticsKeys(msg)
+	case StateProcesses:
+		return m.handleProcessesKeys(msg)
+	case StateCache:
+		return m.handleCacheKeys(msg)
+	case StateThemeCheck:
+		return m.handleThemeCheckKeys(msg)
+	case StateLighthouse:
+		return m.handleLighthouseKeys(msg)
+	case StateLighthouseTrend:
+		return m.handleLighthouseTrendKeys(msg)
+	case StateUnused:
+		return m.handleUnusedKeys(msg)
+	case StateGraph:
+		return m.handleGraphKeys(msg)
+	case StateParams:
+		return m.handleParamsKeys(msg)
+	case StateOutputChanges:
+		return m.handleOutputChangesKeys(msg)
+	case StateFileDiff:
+		return m.handleFileDiffKeys(msg)
 	}
 	return m, nil
 }
@@ -238,28 +444,57 @@ func (m Model) handleMenuKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			m.cursor++
 		}
 	case "enter", " ":
+		m.feedCursor = 0
+		m.statusMessage = ""
 		switch m.cursor {
 		case 0: // Build Theme
-			m.state = StateBuild
-			go func() {
-				m.backend.StartBuild()
-			}()
+			return m.startBuild(DefaultBuildOptions()), nil
 		case 1: // Watch Mode
 			m.state = StateWatch
 			go func() {
 				m.backend.StartWatch(false)
 			}()
 		case 2: // Build with Report
-			m.state = StateBuild
-			go func() {
-				m.backend.StartBuild()
-			}()
-		case 3: // Shopify Watch
+			options := DefaultBuildOptions()
+			options.Profile, options.Report = "report", true
+			return m.startBuild(options), nil
+		case 3: // Build with Options
+			return m.openBuildOptions(m.backend.GetBuildStatus().Options), nil
+		case 4: // Shopify Watch
 			m.state = StateWatch
 			go func() {
 				m.backend.StartWatch(true)
 			}()
-		case 4: // Exit
+		case 5: // NPM Scripts
+			return m.openScripts(), nil
+		case 6: // Jobs
+			return m.openJobs(), nil
+		case 7: // Processes
+			return m.openProcesses(), nil
+		case 8: // Build History
+			return m.openHistory(), nil
+		case 9: // Analytics
+			return m.openAnalytics(), nil
+		case 10: // Build Cache
+			return m.openCache()
+		case 11: // Theme Check
+			return m.openThemeCheck(), nil
+		case 12: // Lighthouse
+			return m.openLighthouse(), nil
+		case 13: // Unused Files
+			return m.openUnused(), nil
+		case 14: // Dependency Graph
+			return m.openGraph(), nil
+		case 15: // Snippet Parameters
+			return m.openParams(), nil
+		case 16: // Build Output Changes
+			return m.openOutputChanges(), nil
+		case 17: // Diagnostics
+			m.state = StateDiagnostics
+			m.diagCursor = 0
+			m.statusMessage = "🔎 Validating section schemas..."
+			return m, validateSchemasCmd(m.backend)
+		case 18: // Exit
 			return m, tea.Quit
 		}
 	}
@@ -272,10 +507,46 @@ func (m Model) handleBuildKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		return m, tea.Quit
 	case "esc":
 		m.state = StateMenu
+	case "up", "k", "down", "j", "e", "enter":
+		return m.handleFeedKeys(msg, logFeed(m.backend.GetLogs(), maxFeedItems))
+	case "d":
+		if !m.backend.GetBuildStatus().IsRunning {
+			return m.openOutputChanges(), nil
+		}
 	}
 	return m, nil
 }
 
+// handleFeedKeys moves through feed entries and opens the selected one in the editor
+func (m Model) handleFeedKeys(msg tea.KeyMsg, items []feedItem) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "up", "k":
+		if m.feedCursor > 0 {
+			m.feedCursor--
+		}
+	case "down", "j":
+		if m.feedCursor < len(items)-1 {
+			m.feedCursor++
+		}
+	case "e", "enter":
+		if m.feedCursor < len(items) {
+			return m, openInEditor(items[m.feedCursor].Ref)
+		}
+	}
+	return m, nil
+}
+
+// restartWatchCmd restarts the watch off the UI goroutine, which would
+// otherwise block until the old watch exits
+func restartWatchCmd(backend *Backend) tea.Cmd {
+	return func() tea.Msg {
+		if err := backend.RestartWatch(); err != nil {
+			return URLActionMsg{Err: fmt.Errorf("failed to restart the watch: %v", err)}
+		}
+		return URLActionMsg{Status: "🔄 Watch restarted"}
+	}
+}
+
 func (m Model) handleWatchKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	switch msg.String() {
 	case "ctrl+c", "q":
@@ -283,17 +554,31 @@ func (m Model) handleWatchKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		m.backend.Cleanup()
 		return m, tea.Quit
 	case "esc":
-		m.backend.StopWatch()
+		// The watch keeps running as a background job
 		m.state = StateMenu
 	case "s":
 		m.backend.StopWatch()
 	case "r":
-		// Restart watch - determine if it was Shopify mode
-		watchStatus := m.backend.GetWatchStatus()
-		isShopify := watchStatus.Mode == "shopify"
-		m.backend.StopWatch()
-		time.Sleep(time.Millisecond * 500) // Brief pause
-		m.backend.StartWatch(isShopify)
+		m.statusMessage = "🔄 Restarting watch..."
+		return m, restartWatchCmd(m.backend)
+	case "up", "k", "down", "j", "e", "enter":
+		return m.handleFeedKeys(msg, watchFeed(m.backend.GetChanges(), m.backend.GetLogs()))
+	case "o":
+		return m, openURL(m.backend.GetWatchStatus().ShopifyURL)
+	case "p":
+		return m, openURL(m.backend.GetWatchStatus().PreviewURL)
+	case "y":
+		return m, copyURL(m.backend.GetWatchStatus().ShopifyURL)
+	case "Y":
+		return m, copyURL(m.backend.GetWatchStatus().PreviewURL)
+	case "v":
+		m.showQR = !m.showQR
+	case "g":
+		if err := m.backend.RequestGC(); err != nil {
+			m.statusMessage = fmt.Sprintf("❌ %v", err)
+		} else {
+			m.statusMessage = "🧹 Garbage collection requested"
+		}
 	}
 	return m, nil
 }
@@ -306,6 +591,42 @@ func (m Model) View() string {
 		return m.renderBuild()
 	case StateWatch:
 		return m.renderWatch()
+	case StateDiagnostics:
+		return m.renderDiagnostics()
+	case StateBuildOptions:
+		return m.renderBuildOptions()
+	case StateScripts:
+		return m.renderScripts()
+	case StateJobs:
+		return m.renderJobs()
+	case StateHistory:
+		return m.renderHistory()
+	case StateReport:
+		return m.renderReport()
+	case StateCompare:
+		return m.renderCompare()
+	case StateAnalytics:
+		return m.renderAnalytics()
+	case StateProcesses:
+		return m.renderProcesses()
+	case StateCache:
+		return m.renderCache()
+	case StateThemeCheck:
+		return m.renderThemeCheck()
+	case StateLighthouse:
+		return m.renderLighthouse()
+	case StateLighthouseTrend:
+		return m.renderLighthouseTrend()
+	case StateUnused:
+		return m.renderUnused()
+	case StateGraph:
+		return m.renderGraph()
+	case StateParams:
+		return m.renderParams()
+	case StateOutputChanges:
+		return m.renderOutputChanges()
+	case StateFileDiff:
+		return m.renderFileDiff()
 	}
 	return ""
 }
@@ -322,6 +643,19 @@ func (m Model) renderMenu() string {
 		}
 	}
 
+	running := 0
+	for _, job := range m.backend.GetJobs() {
+		if job.State == JobRunning {
+			running++
+		}
+	}
+	if running > 0 {
+		s += "\n" + infoStyle.Render(fmt.Sprintf("🧵 %d job(s) running in the background", running)) + "\n"
+	}
+	if banner := m.renderMemoryBanner(); banner != "" {
+		s += "\n" + banner
+	}
+
 	s += "\n" + helpStyle.Render("↑/↓: navigate • enter: select • q: quit") + "\n"
 	return s
 }
@@ -331,8 +665,9 @@ func (m Model) renderBuild() string {
 	s := "\n"
 	s += titleStyle.Render("🔨 BUILD MODE") + "\n\n"
 
-	// Progress bar
-	progress := buildStatus.Progress
+	// Progress bar, weighted by how long each step usually takes
+	now := time.Now()
+	progress := buildStatus.OverallProgress(now)
 	bar := ""
 	for i := 0; i < 20; i++ {
 		if i < progress/5 {
@@ -342,7 +677,18 @@ func (m Model) renderBuild() string {
 		}
 	}
 
-	s += progressStyle.Render(fmt.Sprintf("[%s] %d%%", bar, progress)) + "\n\n"
+	s += progressStyle.Render(fmt.Sprintf("[%s] %d%%", bar, progress)) + "\n"
+	if eta, ok := buildStatus.ETA(now); ok {
+		line := fmt.Sprintf("⏱️  ETA %s", eta.Round(time.Second))
+		if expected, ok := buildStatus.Estimate.Expected(buildStatus.StepName); ok {
+			line += fmt.Sprintf(" • %s usually takes %s", buildStatus.StepName, formatMillis(expected.Milliseconds()))
+		}
+		s += detailStyle.Render(line) + "\n"
+	}
+	if buildStatus.IsRunning {
+		s += detailStyle.Render("Progress weights: "+buildStatus.EstimateSource()) + "\n"
+	}
+	s += "\n"
 
 	// Status information
 	status := "🔄 Running"
@@ -355,9 +701,18 @@ func (m Model) renderBuild() string {
 	}
 
 	s += statusStyle.Render(status) + "\n"
+	if buildStatus.Options.Profile != "" {
+		s += infoStyle.Render(fmt.Sprintf("Profile: %s (%s)", buildStatus.Options.Profile, buildStatus.Options.Summary())) + "\n"
+	}
 	s += infoStyle.Render(fmt.Sprintf("Step: %s", buildStatus.CurrentStep)) + "\n"
 	s += infoStyle.Render(fmt.Sprintf("Message: %s", buildStatus.Message)) + "\n\n"
 
+	// Per-step timeline
+	if len(buildStatus.Steps) > 0 {
+		s += statsStyle.Render("⏳ Step Timeline:") + "\n"
+		s += renderTimeline(buildStatus.Steps, now, m.width) + "\n"
+	}
+
 	// Build statistics
 	if buildStatus.FilesCopied > 0 || buildStatus.CacheHits > 0 {
 		s += statsStyle.Render("📊 Statistics:") + "\n"
@@ -376,7 +731,55 @@ func (m Model) renderBuild() string {
 		s += "\n"
 	}
 
-	s += helpStyle.Render("esc: return to menu • ctrl+c: exit") + "\n"
+	// Live resource usage of the build's process tree, then its peaks
+	if build, ok := m.backend.GetSystemSample().Jobs["build"]; ok && buildStatus.IsRunning {
+		s += detailStyle.Render("🖥️  "+build.Summary()) + "\n\n"
+	} else if buildStatus.Peaks.ProcessCount > 0 {
+		s += detailStyle.Render("🏔️  Peak "+buildStatus.Peaks.Summary()) + "\n\n"
+	}
+
+	if buildStatus.ReportID != "" {
+		s += infoStyle.Render(fmt.Sprintf("📄 Report %s saved (see Build History)", buildStatus.ReportID)) + "\n"
+		if regression := buildStatus.Regression; regression != nil && regression.Regressed {
+			s += errorStyle.Render("🐢 Duration regression: "+regression.Summary()) + "\n"
+		}
+		s += "\n"
+	}
+
+	if errors, warnings := countBySeverity(m.backend.GetDiagnostics()); errors+warnings > 0 {
+		s += errorStyle.Render(fmt.Sprintf("🩺 %d errors, %d warnings (see Diagnostics)", errors, warnings)) + "\n\n"
+	}
+
+	if diff, ok := m.backend.GetOutputDiff(); ok && !buildStatus.IsRunning {
+		s += infoStyle.Render("📦 Output: "+diff.Summary()+" (d: show changes)") + "\n\n"
+	}
+
+	if feed := logFeed(m.backend.GetLogs(), maxFeedItems); len(feed) > 0 {
+		s += statsStyle.Render("🚨 Problems:") + "\n"
+		s += m.renderFeed(feed) + "\n"
+	}
+
+	if m.statusMessage != "" {
+		s += infoStyle.Render(m.statusMessage) + "\n\n"
+	}
+
+	s += helpStyle.Render("↑/↓: select • e: open in editor • d: output changes • esc: return to menu • ctrl+c: exit") + "\n"
+	return s
+}
+
+// renderFeed draws feed entries with the selected one highlighted
+func (m Model) renderFeed(items []feedItem) string {
+	s := ""
+	for i, item := range items {
+		label := truncate(item.Label, 100)
+		if i == m.feedCursor {
+			s += selectedStyle.Render("> "+label) + "\n"
+		} else if item.Level == "error" {
+			s += errorStyle.Render("  "+label) + "\n"
+		} else {
+			s += detailStyle.Render("  "+label) + "\n"
+		}
+	}
 	return s
 }
 
@@ -396,7 +799,8 @@ func (m Model) renderWatch() string {
 	if watchStatus.IsActive {
 		status = "✅ WATCHING"
 	}
-	s += statusStyle.Render(status) + "\n\n"
+	s += statusStyle.Render(status) + "\n"
+	s += m.renderMemoryBanner() + "\n"
 
 	// Watch statistics
 	s += statsStyle.Render("📊 Statistics:") + "\n"
@@ -451,13 +855,39 @@ func (m Model) renderWatch() string {
 		if watchStatus.PreviewURL != "" {
 			s += detailStyle.Render(fmt.Sprintf("  Preview: %s", watchStatus.PreviewURL)) + "\n"
 		}
+		if m.showQR && watchStatus.PreviewURL != "" {
+			if qr, err := encodeQR(watchStatus.PreviewURL); err == nil {
+				s += "\n" + normalStyle.Render(qr.Render())
+			} else {
+				s += errorStyle.Render(fmt.Sprintf("  QR code unavailable: %v", err)) + "\n"
+			}
+		}
+	}
+
+	if errors, warnings := countBySeverity(m.backend.GetDiagnostics()); errors+warnings > 0 {
+		s += "\n" + errorStyle.Render(fmt.Sprintf("🩺 %d errors, %d warnings (see Diagnostics)", errors, warnings)) + "\n"
+	}
+
+	s += m.renderMemory()
+	s += m.renderLint()
+
+	if feed := watchFeed(m.backend.GetChanges(), m.backend.GetLogs()); len(feed) > 0 {
+		s += "\n" + statsStyle.Render("📝 Change Feed:") + "\n"
+		s += m.renderFeed(feed)
+	}
+
+	if m.statusMessage != "" {
+		s += "\n" + infoStyle.Render(m.statusMessage) + "\n"
 	}
 
 	s += "\n"
 
 	// Help text with controls
-	helpText := "s: stop watch • r: restart watch • esc: return to menu • q: quit and cleanup"
+	helpText := "↑/↓: select • e: open in editor • s: stop watch • r: restart watch • g: request GC • esc: menu (watch keeps running) • q: quit and cleanup"
 	s += helpStyle.Render(helpText) + "\n"
+	if watchStatus.Mode == "shopify" {
+		s += helpStyle.Render("o/p: open local/preview URL • y/Y: copy local/preview URL • v: toggle preview QR code") + "\n"
+	}
 
 	return s
 }
@@ -496,9 +926,17 @@ var (
 	helpStyle = lipgloss.NewStyle().
 			Foreground(lipgloss.Color("#6272A4")).
 			Italic(true)
+
+	errorStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#FF5555"))
 )
 
 func main() {
+	// Subcommands run headless, without the TUI or its cleanup output
+	if len(os.Args) > 1 {
+		os.Exit(runCommand(os.Args[1:]))
+	}
+
 	// Initialize backend
 	backend := NewBackend()
 
@@ -532,8 +970,7 @@ func main() {
 	}()
 
 	// Create and run the Bubble Tea app
-	model := initialModel()
-	model.backend = backend
+	model := initialModel(backend)
 	model.ctx = ctx
 	model.cancel = cancel
 
@@ -0,0 +1,259 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Memory policies for a watch that stays above the memory threshold, set as memory_policy in settings.json
+const (
+	MemoryPolicyNotify  = "notify"
+	MemoryPolicyGC      = "gc"
+	MemoryPolicyRestart = "restart"
+)
+
+const (
+	// defaultMemoryThresholdMB matches performance.memoryThreshold in unified-config.js
+	defaultMemoryThresholdMB = 512
+	defaultMemorySustain     = time.Minute
+	// The engine samples its heap every 30s and warns while it is high, so
+	// without a warning for this long the heap is back below the limit
+	memoryWarningTimeout = 75 * time.Second
+	// Minimum time between automatic GC requests
+	memoryGCCooldown = time.Minute
+	// Points kept per chart series
+	maxMemoryPoints = 120
+)
+
+// MemoryPoint is one memory reading
+type MemoryPoint struct {
+	At    time.Time
+	Bytes int64
+}
+
+// MemoryStatus tracks the watch engine's memory and the policy applied when it runs high
+type MemoryStatus struct {
+	// Heap readings come from memory_sample events, RSS from the process sampler
+	Heap      []MemoryPoint
+	RSS       []MemoryPoint
+	HeapPeak  int64
+	Threshold int64
+	Policy    string
+	Sustain   time.Duration
+	// AboveSince is when the heap crossed the threshold; zero while it is below
+	AboveSince  time.Time
+	LastWarning time.Time
+	LastAction  string
+	lastGC      time.Time
+	restarted   bool
+}
+
+// configure applies the memory settings
+func (s *MemoryStatus) configure(settings Settings) {
+	s.Threshold = int64(settings.MemoryThreshold) * 1024 * 1024
+	s.Policy = settings.MemoryPolicy
+	s.Sustain = time.Duration(settings.MemorySustain) * time.Second
+}
+
+// Above reports whether the heap is currently over the threshold
+func (s MemoryStatus) Above(now time.Time) bool {
+	return !s.AboveSince.IsZero() && now.Sub(s.LastWarning) < memoryWarningTimeout
+}
+
+// CurrentHeap returns the latest heap reading
+func (s MemoryStatus) CurrentHeap() int64 {
+	if len(s.Heap) == 0 {
+		return 0
+	}
+	return s.Heap[len(s.Heap)-1].Bytes
+}
+
+func appendMemoryPoint(points []MemoryPoint, point MemoryPoint) []MemoryPoint {
+	points = append(points, point)
+	if len(points) > maxMemoryPoints {
+		points = points[len(points)-maxMemoryPoints:]
+	}
+	return points
+}
+
+// parseByteSize reads sizes like "612.5 MB" as printed by the engine's formatBytes
+func parseByteSize(size string) (int64, error) {
+	fields := strings.Fields(size)
+	if len(fields) != 2 {
+		return 0, fmt.Errorf("invalid size %q", size)
+	}
+	value, err := strconv.ParseFloat(fields[0], 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid size %q", size)
+	}
+	multipliers := map[string]float64{"B": 1, "KB": 1 << 10, "MB": 1 << 20, "GB": 1 << 30}
+	multiplier, ok := multipliers[strings.ToUpper(fields[1])]
+	if !ok {
+		return 0, fmt.Errorf("invalid size unit %q", fields[1])
+	}
+	return int64(value * multiplier), nil
+}
+
+// handleMemorySample charts a memory_sample event, the heap reading the engine
+// reports every 30s. Caller must hold the mutex.
+func (b *Backend) handleMemorySample(current, peak string) {
+	heap, err := parseByteSize(current)
+	if err != nil {
+		return
+	}
+	status := &b.memory
+	status.Heap = appendMemoryPoint(status.Heap, MemoryPoint{At: time.Now(), Bytes: heap})
+	if peakBytes, err := parseByteSize(peak); err == nil {
+		status.HeapPeak = max(status.HeapPeak, peakBytes)
+	}
+	if heap < status.Threshold {
+		status.AboveSince = time.Time{}
+	}
+}
+
+// handleMemoryWarning applies the memory policy to a memory_warning event. The
+// sample sent just before it already charted the reading. Caller must hold the mutex.
+func (b *Backend) handleMemoryWarning(current string) {
+	heap, err := parseByteSize(current)
+	if err != nil || heap < b.memory.Threshold {
+		return
+	}
+	now := time.Now()
+	status := &b.memory
+
+	if !status.Above(now) {
+		status.AboveSince = now
+		status.restarted = false
+		b.logEvent("warning", fmt.Sprintf("Watch heap %s is above the %s limit", formatBytes(heap), formatBytes(status.Threshold)), "watch")
+	}
+	status.LastWarning = now
+
+	switch status.Policy {
+	case MemoryPolicyGC:
+		if now.Sub(status.lastGC) >= memoryGCCooldown {
+			status.lastGC = now
+			if err := b.sendWatchCommand("gc"); err == nil {
+				status.LastAction = "GC requested at " + now.Format("15:04:05")
+			}
+		}
+	case MemoryPolicyRestart:
+		if !status.restarted && now.Sub(status.AboveSince) >= status.Sustain {
+			status.restarted = true
+			status.LastAction = "Watch restarted at " + now.Format("15:04:05")
+			b.logEvent("warning", fmt.Sprintf("Restarting the watch after %s above the memory limit", status.Sustain), "watch")
+			go b.restartWatch()
+		}
+	}
+}
+
+// recordWatchRSS adds the watch tree's resident memory to the chart. Caller must hold the mutex.
+func (b *Backend) recordWatchRSS(sample SystemSample) {
+	if watch, ok := sample.Jobs["watch"]; ok {
+		b.memory.RSS = appendMemoryPoint(b.memory.RSS, MemoryPoint{At: sample.At, Bytes: int64(watch.MemoryUsage * 1024 * 1024)})
+	}
+}
+
+// sendWatchCommand writes a command line to the watch adapter's stdin. Caller must hold the mutex.
+func (b *Backend) sendWatchCommand(command string) error {
+	if b.watchStdin == nil || !b.jobs.IsRunning("watch") {
+		return fmt.Errorf("no watch process running")
+	}
+	if _, err := fmt.Fprintln(b.watchStdin, command); err != nil {
+		return fmt.Errorf("failed to send %s to the watch: %v", command, err)
+	}
+	return nil
+}
+
+// RequestGC asks the watch engine to collect garbage
+func (b *Backend) RequestGC() error {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	if err := b.sendWatchCommand("gc"); err != nil {
+		return err
+	}
+	b.memory.lastGC = time.Now()
+	b.memory.LastAction = "GC requested at " + b.memory.lastGC.Format("15:04:05")
+	return nil
+}
+
+// restartWatch restarts the watch for the memory policy, logging a failure
+func (b *Backend) restartWatch() {
+	if err := b.RestartWatch(); err != nil {
+		b.mutex.Lock()
+		b.logEvent("error", fmt.Sprintf("Failed to restart the watch: %v", err), "watch")
+		b.mutex.Unlock()
+	}
+}
+
+// GetMemoryStatus returns a copy of the watch memory status
+func (b *Backend) GetMemoryStatus() MemoryStatus {
+	b.mutex.RLock()
+	defer b.mutex.RUnlock()
+	status := b.memory
+	status.Heap = append([]MemoryPoint(nil), status.Heap...)
+	status.RSS = append([]MemoryPoint(nil), status.RSS...)
+	return status
+}
+
+// sparkline draws the last width points scaled to ceiling
+func sparkline(points []MemoryPoint, width int, ceiling int64) string {
+	const levels = "▁▂▃▄▅▆▇█"
+	blocks := []rune(levels)
+	if len(points) > width {
+		points = points[len(points)-width:]
+	}
+	for _, point := range points {
+		ceiling = max(ceiling, point.Bytes)
+	}
+	if ceiling <= 0 {
+		return ""
+	}
+
+	var line strings.Builder
+	for _, point := range points {
+		level := int(float64(point.Bytes) / float64(ceiling) * float64(len(blocks)-1))
+		line.WriteRune(blocks[min(max(level, 0), len(blocks)-1)])
+	}
+	return line.String()
+}
+
+// padChart pads a sparkline to width cells
+func padChart(chart string, width int) string {
+	return chart + strings.Repeat(" ", max(width-len([]rune(chart)), 0))
+}
+
+// renderMemoryBanner warns while the watch heap is over the threshold
+func (m Model) renderMemoryBanner() string {
+	status := m.backend.GetMemoryStatus()
+	if !status.Above(time.Now()) {
+		return ""
+	}
+	return errorStyle.Render(fmt.Sprintf("⚠️  Watch heap %s is above the %s limit for %s (policy: %s)",
+		formatBytes(status.CurrentHeap()), formatBytes(status.Threshold),
+		time.Since(status.AboveSince).Round(time.Second), status.Policy)) + "\n"
+}
+
+// renderMemory draws the watch heap and RSS charts
+func (m Model) renderMemory() string {
+	status := m.backend.GetMemoryStatus()
+	if len(status.Heap) == 0 && len(status.RSS) == 0 {
+		return ""
+	}
+
+	s := "\n" + statsStyle.Render(fmt.Sprintf("🧠 Memory (limit %s, policy %s):", formatBytes(status.Threshold), status.Policy)) + "\n"
+	const chartWidth = 40
+	if len(status.Heap) > 0 {
+		s += detailStyle.Render(fmt.Sprintf("  Heap %s %s (peak %s)", padChart(sparkline(status.Heap, chartWidth, status.Threshold), chartWidth),
+			formatBytes(status.CurrentHeap()), formatBytes(status.HeapPeak))) + "\n"
+	}
+	if len(status.RSS) > 0 {
+		s += detailStyle.Render(fmt.Sprintf("  RSS  %s %s", padChart(sparkline(status.RSS, chartWidth, status.Threshold), chartWidth),
+			formatBytes(status.RSS[len(status.RSS)-1].Bytes))) + "\n"
+	}
+	if status.LastAction != "" {
+		s += detailStyle.Render("  "+status.LastAction) + "\n"
+	}
+	return s
+}
@@ -0,0 +1,607 @@
+package main
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// maxDiffBytes is the largest output file that still gets a text diff
+const maxDiffBytes = 2 * 1024 * 1024
+
+// maxDiffEdits bounds the edit distance the line diff searches for; beyond it
+// the changed region is shown as removed and re-added whole
+const maxDiffEdits = 2000
+
+// OutputFile is one file of the build output in a manifest
+type OutputFile struct {
+	Size int64  `json:"size"`
+	Hash string `json:"hash"`
+}
+
+// OutputManifest maps the paths of Curalife-Theme-Build, relative to it, to
+// their size and content hash
+type OutputManifest map[string]OutputFile
+
+// outputSnapshotDir keeps the pre-build copies of diffable output files, named
+// by content hash, so they can be diffed once the build has overwritten them
+func outputSnapshotDir() string {
+	return filepath.Join(cacheDir(), "output-snapshot")
+}
+
+// diffableOutput reports whether an output file gets a text diff
+func diffableOutput(path string) bool {
+	switch filepath.Ext(path) {
+	case ".liquid", ".json", ".css", ".js":
+		return true
+	}
+	return false
+}
+
+// hashFile returns the SHA-256 of a file's content
+func hashFile(path string) (string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+	hash := sha256.New()
+	if _, err := io.Copy(hash, file); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// readOutputManifest hashes every file of the build output. Hidden
+// directories such as .shopify are not part of what gets pushed.
+func readOutputManifest() (OutputManifest, error) {
+	root := filepath.Join(projectRoot(), buildDirName)
+	manifest := make(OutputManifest)
+	err := filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) && path == root {
+				return filepath.SkipDir
+			}
+			return err
+		}
+		if info.IsDir() {
+			if path != root && strings.HasPrefix(info.Name(), ".") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		hash, err := hashFile(path)
+		if err != nil {
+			return err
+		}
+		relative, _ := filepath.Rel(root, path)
+		manifest[filepath.ToSlash(relative)] = OutputFile{Size: info.Size(), Hash: hash}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %v", buildDirName, err)
+	}
+	return manifest, nil
+}
+
+// snapshotOutput records the manifest of the output as it is before a build
+// and keeps a copy of every diffable file. Copies no longer in the output are
+// dropped, so the snapshot only ever holds one build's worth of files.
+func snapshotOutput() (OutputManifest, error) {
+	manifest, err := readOutputManifest()
+	if err != nil {
+		return nil, err
+	}
+	dir := outputSnapshotDir()
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create %s: %v", dir, err)
+	}
+
+	keep := make(map[string]bool)
+	for path, file := range manifest {
+		if !diffableOutput(path) || file.Size > maxDiffBytes {
+			continue
+		}
+		keep[file.Hash] = true
+		copyPath := filepath.Join(dir, file.Hash)
+		if _, err := os.Stat(copyPath); err == nil {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(projectRoot(), buildDirName, filepath.FromSlash(path)))
+		if err != nil {
+			return nil, err
+		}
+		if err := os.WriteFile(copyPath, data, 0644); err != nil {
+			return nil, fmt.Errorf("failed to snapshot %s: %v", path, err)
+		}
+	}
+
+	entries, _ := os.ReadDir(dir)
+	for _, entry := range entries {
+		if !keep[entry.Name()] {
+			os.Remove(filepath.Join(dir, entry.Name()))
+		}
+	}
+	return manifest, nil
+}
+
+// OutputChange is a file the build added, removed or modified
+type OutputChange struct {
+	Path   string `json:"path"`
+	Status string `json:"status"` // added, removed or modified
+	Before int64  `json:"before"`
+	After  int64  `json:"after"`
+	// Hash of the file before the build, naming its snapshot copy
+	BeforeHash string `json:"-"`
+}
+
+// SizeDelta is how much the file grew, negative when it shrank
+func (c OutputChange) SizeDelta() int64 {
+	return c.After - c.Before
+}
+
+// Diffable reports whether the change can be shown as a text diff
+func (c OutputChange) Diffable() bool {
+	return diffableOutput(c.Path) && c.Before <= maxDiffBytes && c.After <= maxDiffBytes
+}
+
+// OutputDiff compares the build output before and after a build
+type OutputDiff struct {
+	Changes    []OutputChange `json:"changes"`
+	Unchanged  int            `json:"unchanged"`
+	ComparedAt time.Time      `json:"compared_at"`
+}
+
+// Count returns how many changes have the status
+func (d OutputDiff) Count(status string) int {
+	count := 0
+	for _, change := range d.Changes {
+		if change.Status == status {
+			count++
+		}
+	}
+	return count
+}
+
+// Summary describes the diff in one line
+func (d OutputDiff) Summary() string {
+	return fmt.Sprintf("%d added, %d removed, %d modified, %d unchanged",
+		d.Count("added"), d.Count("removed"), d.Count("modified"), d.Unchanged)
+}
+
+// diffOutput compares two manifests, listing changes by path
+func diffOutput(before, after OutputManifest) OutputDiff {
+	diff := OutputDiff{ComparedAt: time.Now()}
+	for path, old := range before {
+		current, ok := after[path]
+		switch {
+		case !ok:
+			diff.Changes = append(diff.Changes, OutputChange{Path: path, Status: "removed", Before: old.Size, BeforeHash: old.Hash})
+		case current.Hash != old.Hash:
+			diff.Changes = append(diff.Changes, OutputChange{Path: path, Status: "modified", Before: old.Size, After: current.Size, BeforeHash: old.Hash})
+		default:
+			diff.Unchanged++
+		}
+	}
+	for path, current := range after {
+		if _, ok := before[path]; !ok {
+			diff.Changes = append(diff.Changes, OutputChange{Path: path, Status: "added", After: current.Size})
+		}
+	}
+	sort.Slice(diff.Changes, func(i, j int) bool { return diff.Changes[i].Path < diff.Changes[j].Path })
+	return diff
+}
+
+// lineEdit is one line of an edit script: ' ' kept, '-' removed or '+' added
+type lineEdit struct {
+	Op   byte
+	Line string
+}
+
+// diffLines returns the edit script turning a into b. Common leading and
+// trailing lines are split off before Myers' algorithm runs on the rest.
+func diffLines(a, b []string) []lineEdit {
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(a)-prefix && suffix < len(b)-prefix && a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+
+	var edits []lineEdit
+	for _, line := range a[:prefix] {
+		edits = append(edits, lineEdit{' ', line})
+	}
+	middleA, middleB := a[prefix:len(a)-suffix], b[prefix:len(b)-suffix]
+	if middle, ok := myersDiff(middleA, middleB); ok {
+		edits = append(edits, middle...)
+	} else {
+		for _, line := range middleA {
+			edits = append(edits, lineEdit{'-', line})
+		}
+		for _, line := range middleB {
+			edits = append(edits, lineEdit{'+', line})
+		}
+	}
+	for _, line := range a[len(a)-suffix:] {
+		edits = append(edits, lineEdit{' ', line})
+	}
+	return edits
+}
+
+// myersDiff finds a shortest edit script, giving up past maxDiffEdits edits
+func myersDiff(a, b []string) ([]lineEdit, bool) {
+	n, m := len(a), len(b)
+	limit := min(n+m, maxDiffEdits)
+	offset := limit + 1
+	v := make([]int, 2*limit+3)
+	// trace[d] holds v for diagonals -d..d as it was when round d started
+	var trace [][]int
+
+	for d := 0; d <= limit; d++ {
+		trace = append(trace, append([]int(nil), v[offset-d:offset+d+1]...))
+		for k := -d; k <= d; k += 2 {
+			var x int
+			if k == -d || (k != d && v[offset+k-1] < v[offset+k+1]) {
+				x = v[offset+k+1]
+			} else {
+				x = v[offset+k-1] + 1
+			}
+			y := x - k
+			for x < n && y < m && a[x] == b[y] {
+				x++
+				y++
+			}
+			v[offset+k] = x
+			if x >= n && y >= m {
+				return backtrackEdits(a, b, trace), true
+			}
+		}
+	}
+	return nil, false
+}
+
+// backtrackEdits walks the trace of myersDiff back from the end of both inputs
+func backtrackEdits(a, b []string, trace [][]int) []lineEdit {
+	var edits []lineEdit
+	x, y := len(a), len(b)
+	for d := len(trace) - 1; d > 0; d-- {
+		v := trace[d]
+		k := x - y
+		previous := k - 1
+		if k == -d || (k != d && v[k-1+d] < v[k+1+d]) {
+			previous = k + 1
+		}
+		previousX := v[previous+d]
+		previousY := previousX - previous
+		for x > previousX && y > previousY {
+			x--
+			y--
+			edits = append(edits, lineEdit{' ', a[x]})
+		}
+		if x == previousX {
+			y--
+			edits = append(edits, lineEdit{'+', b[y]})
+		} else {
+			x--
+			edits = append(edits, lineEdit{'-', a[x]})
+		}
+	}
+	for x > 0 && y > 0 {
+		x--
+		y--
+		edits = append(edits, lineEdit{' ', a[x]})
+	}
+	for i, j := 0, len(edits)-1; i < j; i, j = i+1, j-1 {
+		edits[i], edits[j] = edits[j], edits[i]
+	}
+	return edits
+}
+
+// splitLines splits text into lines without their line endings
+func splitLines(text string) []string {
+	if text == "" {
+		return nil
+	}
+	text = strings.ReplaceAll(text, "\r\n", "\n")
+	return strings.Split(strings.TrimSuffix(text, "\n"), "\n")
+}
+
+// unifiedDiff formats the edits between two texts as unified diff hunks with
+// context lines around each change
+func unifiedDiff(before, after string, context int) []string {
+	edits := diffLines(splitLines(before), splitLines(after))
+
+	var lines []string
+	for start := 0; start < len(edits); {
+		// Find the next change and the run of changes close enough to share a hunk
+		first := start
+		for first < len(edits) && edits[first].Op == ' ' {
+			first++
+		}
+		if first == len(edits) {
+			break
+		}
+		last := first
+		for i := first; i < len(edits); i++ {
+			if edits[i].Op != ' ' {
+				last = i
+			} else if i-last > 2*context {
+				break
+			}
+		}
+		from, to := max(first-context, start), min(last+context+1, len(edits))
+
+		// Line numbers of the hunk in both files
+		oldLine, newLine := 1, 1
+		for _, edit := range edits[:from] {
+			if edit.Op != '+' {
+				oldLine++
+			}
+			if edit.Op != '-' {
+				newLine++
+			}
+		}
+		oldCount, newCount := 0, 0
+		var body []string
+		for _, edit := range edits[from:to] {
+			if edit.Op != '+' {
+				oldCount++
+			}
+			if edit.Op != '-' {
+				newCount++
+			}
+			body = append(body, string(edit.Op)+edit.Line)
+		}
+		if oldCount == 0 {
+			oldLine--
+		}
+		if newCount == 0 {
+			newLine--
+		}
+		lines = append(lines, fmt.Sprintf("@@ -%s +%s @@", hunkRange(oldLine, oldCount), hunkRange(newLine, newCount)))
+		lines = append(lines, body...)
+		start = to
+	}
+	return lines
+}
+
+// hunkRange formats the start and length of one side of a hunk header, leaving
+// out a length of one like diff -u does
+func hunkRange(start, count int) string {
+	if count == 1 {
+		return fmt.Sprint(start)
+	}
+	return fmt.Sprintf("%d,%d", start, count)
+}
+
+// readDiffable reads one side of a text diff, refusing binary content
+func readDiffable(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	if bytes.IndexByte(data, 0) >= 0 {
+		return "", fmt.Errorf("%s is binary", filepath.Base(path))
+	}
+	return string(data), nil
+}
+
+// changeDiff diffs an output file against its pre-build snapshot copy
+func changeDiff(change OutputChange) ([]string, error) {
+	if !change.Diffable() {
+		return nil, fmt.Errorf("%s has no text diff", change.Path)
+	}
+	var before, after string
+	var err error
+	if change.Status != "added" {
+		if before, err = readDiffable(filepath.Join(outputSnapshotDir(), change.BeforeHash)); err != nil {
+			return nil, fmt.Errorf("no snapshot of %s before the build: %v", change.Path, err)
+		}
+	}
+	if change.Status != "removed" {
+		if after, err = readDiffable(filepath.Join(projectRoot(), buildDirName, filepath.FromSlash(change.Path))); err != nil {
+			return nil, err
+		}
+	}
+	lines := []string{"--- a/" + change.Path, "+++ b/" + change.Path}
+	return append(lines, unifiedDiff(before, after, 3)...), nil
+}
+
+// diffBuildOutput compares the output tree with its snapshot once a build exits
+func (b *Backend) diffBuildOutput() {
+	b.mutex.RLock()
+	before := b.outputBefore
+	b.mutex.RUnlock()
+	if before == nil {
+		return
+	}
+	after, err := readOutputManifest()
+	if err != nil {
+		b.jobs.Log("build", "⚠️  Output diff skipped: "+err.Error())
+		return
+	}
+	diff := diffOutput(before, after)
+	b.jobs.Log("build", "📦 Output: "+diff.Summary())
+
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	b.outputDiff = &diff
+}
+
+// GetOutputDiff returns how the last build changed the output
+func (b *Backend) GetOutputDiff() (OutputDiff, bool) {
+	b.mutex.RLock()
+	defer b.mutex.RUnlock()
+	if b.outputDiff == nil {
+		return OutputDiff{}, false
+	}
+	return *b.outputDiff, true
+}
+
+// outputStatusIcon marks a change in the output changes list
+func outputStatusIcon(status string) string {
+	switch status {
+	case "added":
+		return "+"
+	case "removed":
+		return "-"
+	}
+	return "~"
+}
+
+// formatSizeDelta shows a size change with its sign
+func formatSizeDelta(delta int64) string {
+	switch {
+	case delta > 0:
+		return "+" + formatBytes(delta)
+	case delta < 0:
+		return "-" + formatBytes(-delta)
+	}
+	return "±0"
+}
+
+func (m Model) openOutputChanges() Model {
+	m.state = StateOutputChanges
+	m.outputDiff, _ = m.backend.GetOutputDiff()
+	m.outputCursor = 0
+	m.statusMessage = ""
+	return m
+}
+
+func (m Model) handleOutputChangesKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	changes := m.outputDiff.Changes
+	switch msg.String() {
+	case "ctrl+c", "q":
+		m.backend.Cleanup()
+		return m, tea.Quit
+	case "esc":
+		m.state = StateMenu
+	case "up", "k":
+		if m.outputCursor > 0 {
+			m.outputCursor--
+		}
+	case "down", "j":
+		if m.outputCursor < len(changes)-1 {
+			m.outputCursor++
+		}
+	case "enter", "d":
+		if m.outputCursor < len(changes) {
+			lines, err := changeDiff(changes[m.outputCursor])
+			if err != nil {
+				m.statusMessage = fmt.Sprintf("❌ %v", err)
+				return m, nil
+			}
+			m.state = StateFileDiff
+			m.fileDiff = lines
+			m.fileDiffScroll = 0
+			m.statusMessage = ""
+		}
+	case "e":
+		if m.outputCursor < len(changes) && changes[m.outputCursor].Status != "removed" {
+			return m, openInEditor(FileRef{Path: filepath.Join(projectRoot(), buildDirName, changes[m.outputCursor].Path)})
+		}
+	case "r":
+		cursor := m.outputCursor
+		m = m.openOutputChanges()
+		m.outputCursor = min(cursor, max(len(m.outputDiff.Changes)-1, 0))
+	}
+	return m, nil
+}
+
+func (m Model) renderOutputChanges() string {
+	s := "\n"
+	s += titleStyle.Render("📦 BUILD OUTPUT CHANGES") + "\n\n"
+
+	diff := m.outputDiff
+	if diff.ComparedAt.IsZero() {
+		s += detailStyle.Render(fmt.Sprintf("No build has run yet — the output in %s is compared before and after each build", buildDirName)) + "\n"
+	} else {
+		s += statsStyle.Render(diff.Summary()) + "  " + detailStyle.Render("at "+diff.ComparedAt.Format("15:04:05")) + "\n\n"
+
+		// Keep the cursor visible in a window of changes
+		visible := m.visibleLines() - 2
+		start := 0
+		if m.outputCursor >= visible {
+			start = m.outputCursor - visible + 1
+		}
+		for i := start; i < len(diff.Changes) && i < start+visible; i++ {
+			change := diff.Changes[i]
+			line := fmt.Sprintf("%s %-10s %10s  %s", outputStatusIcon(change.Status), formatSizeDelta(change.SizeDelta()),
+				formatBytes(change.After), change.Path)
+			line = truncate(line, max(m.width-4, 60))
+			switch {
+			case i == m.outputCursor:
+				s += selectedStyle.Render("> "+line) + "\n"
+			case change.Status == "removed":
+				s += errorStyle.Render("  "+line) + "\n"
+			default:
+				s += normalStyle.Render("  "+line) + "\n"
+			}
+		}
+		if len(diff.Changes) == 0 {
+			s += detailStyle.Render("The build left every output file as it was") + "\n"
+		}
+	}
+
+	if m.statusMessage != "" {
+		s += "\n" + infoStyle.Render(m.statusMessage) + "\n"
+	}
+
+	s += "\n" + helpStyle.Render("↑/↓: select • enter: diff • e: open • r: refresh • esc: return to menu") + "\n"
+	return s
+}
+
+func (m Model) handleFileDiffKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "ctrl+c", "q":
+		m.backend.Cleanup()
+		return m, tea.Quit
+	case "esc":
+		m.state = StateOutputChanges
+	default:
+		m.fileDiffScroll = m.scrollLines(msg, m.fileDiffScroll, len(m.fileDiff))
+	}
+	return m, nil
+}
+
+func (m Model) renderFileDiff() string {
+	s := "\n"
+	s += titleStyle.Render("📦 OUTPUT DIFF") + "\n\n"
+
+	width := max(m.width-2, 60)
+	lines := make([]string, len(m.fileDiff))
+	for i, line := range m.fileDiff {
+		line = truncate(line, width)
+		switch {
+		case i < 2:
+			lines[i] = statsStyle.Render(line)
+		case strings.HasPrefix(line, "@@"):
+			lines[i] = statusStyle.Render(line)
+		case strings.HasPrefix(line, "+"):
+			lines[i] = selectedStyle.Render(line)
+		case strings.HasPrefix(line, "-"):
+			lines[i] = errorStyle.Render(line)
+		default:
+			lines[i] = detailStyle.Render(line)
+		}
+	}
+	if len(m.fileDiff) <= 2 {
+		lines = append(lines, detailStyle.Render("Only the content hash changed, e.g. line endings"))
+	}
+	s += m.renderScrolled(lines, m.fileDiffScroll)
+
+	s += "\n" + helpStyle.Render("↑/↓/pgup/pgdn: scroll • esc: back to changes") + "\n"
+	return s
+}
@@ -0,0 +1,129 @@
+package main
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestUnifiedDiff(t *testing.T) {
+	tests := []struct {
+		name   string
+		before string
+		after  string
+		want   []string
+	}{
+		{
+			name:   "identical",
+			before: "a\nb\n",
+			after:  "a\nb\n",
+			want:   nil,
+		},
+		{
+			name:   "line endings only",
+			before: "a\r\nb\r\n",
+			after:  "a\nb\n",
+			want:   nil,
+		},
+		{
+			name:   "one changed line with context",
+			before: "1\n2\n3\n4\n5\n6\n7\n8\n9\n",
+			after:  "1\n2\n3\n4\nfive\n6\n7\n8\n9\n",
+			want:   []string{"@@ -2,7 +2,7 @@", " 2", " 3", " 4", "-5", "+five", " 6", " 7", " 8"},
+		},
+		{
+			name:   "new file",
+			before: "",
+			after:  "x\ny\n",
+			want:   []string{"@@ -0,0 +1,2 @@", "+x", "+y"},
+		},
+		{
+			name:   "removed file",
+			before: "x\n",
+			after:  "",
+			want:   []string{"@@ -1 +0,0 @@", "-x"},
+		},
+		{
+			name:   "distant changes get separate hunks",
+			before: "a\n1\n2\n3\n4\n5\n6\n7\n8\nb\n",
+			after:  "A\n1\n2\n3\n4\n5\n6\n7\n8\nB\n",
+			want: []string{
+				"@@ -1,4 +1,4 @@", "-a", "+A", " 1", " 2", " 3",
+				"@@ -7,4 +7,4 @@", " 6", " 7", " 8", "-b", "+B",
+			},
+		},
+		{
+			name:   "nearby changes share a hunk",
+			before: "a\n1\n2\n3\nb\n",
+			after:  "A\n1\n2\n3\nB\n",
+			want:   []string{"@@ -1,5 +1,5 @@", "-a", "+A", " 1", " 2", " 3", "-b", "+B"},
+		},
+		{
+			name:   "insertion keeps the surrounding lines",
+			before: "a\nb\n",
+			after:  "a\nnew\nb\n",
+			want:   []string{"@@ -1,2 +1,3 @@", " a", "+new", " b"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := unifiedDiff(test.before, test.after, 3); !reflect.DeepEqual(got, test.want) {
+				t.Errorf("unifiedDiff =\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(test.want, "\n"))
+			}
+		})
+	}
+}
+
+func TestDiffLinesIsMinimal(t *testing.T) {
+	a := strings.Split("a b c a b b a", " ")
+	b := strings.Split("c b a b a c", " ")
+	edits := diffLines(a, b)
+	changed := 0
+	var rebuilt []string
+	for _, edit := range edits {
+		if edit.Op != ' ' {
+			changed++
+		}
+		if edit.Op != '-' {
+			rebuilt = append(rebuilt, edit.Line)
+		}
+	}
+	if !reflect.DeepEqual(rebuilt, b) {
+		t.Errorf("applying the edits gives %v, want %v", rebuilt, b)
+	}
+	// The classic example from Myers' paper has an edit distance of 5
+	if changed != 5 {
+		t.Errorf("%d edits, want 5", changed)
+	}
+}
+
+func TestDiffOutput(t *testing.T) {
+	before := OutputManifest{
+		"assets/app.js":        {Size: 100, Hash: "1"},
+		"assets/old.css":       {Size: 40, Hash: "2"},
+		"snippets/card.liquid": {Size: 10, Hash: "3"},
+	}
+	after := OutputManifest{
+		"assets/app.js":        {Size: 130, Hash: "4"},
+		"assets/new.css":       {Size: 50, Hash: "5"},
+		"snippets/card.liquid": {Size: 10, Hash: "3"},
+	}
+	diff := diffOutput(before, after)
+	want := []OutputChange{
+		{Path: "assets/app.js", Status: "modified", Before: 100, After: 130, BeforeHash: "1"},
+		{Path: "assets/new.css", Status: "added", After: 50},
+		{Path: "assets/old.css", Status: "removed", Before: 40, BeforeHash: "2"},
+	}
+	if !reflect.DeepEqual(diff.Changes, want) {
+		t.Errorf("changes = %+v, want %+v", diff.Changes, want)
+	}
+	if diff.Unchanged != 1 {
+		t.Errorf("unchanged = %d, want 1", diff.Unchanged)
+	}
+	if got := diff.Changes[0].SizeDelta(); got != 30 {
+		t.Errorf("size delta = %d, want 30", got)
+	}
+	if got := diff.Summary(); got != "1 added, 1 removed, 1 modified, 1 unchanged" {
+		t.Errorf("summary = %q", got)
+	}
+}
@@ -0,0 +1,217 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"syscall"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// processSignals are the signals the Processes screen can send, by key
+var processSignals = map[string]syscall.Signal{
+	"i": syscall.SIGINT,
+	"t": syscall.SIGTERM,
+	"K": syscall.SIGKILL,
+}
+
+// SignalProcess sends sig to a process in a job's tree. Signalling a job's own
+// process counts as stopping the job; for any process the job log records it
+// and the sampler is woken so the tree reflects the result.
+func (b *Backend) SignalProcess(pid int, sig syscall.Signal) error {
+	var target ProcessInfo
+	for _, process := range b.GetSystemSample().Processes {
+		if process.PID == pid {
+			target = process
+		}
+	}
+	// Only processes started by the TUI may be signalled
+	if target.PID == 0 {
+		return fmt.Errorf("process %d is not part of a running job", pid)
+	}
+
+	job, ok := b.jobs.Get(target.Job)
+	if !ok || job.State != JobRunning {
+		return fmt.Errorf("process %d is not part of a running job", pid)
+	}
+	// The sample can be two seconds old, so make sure the PID has not exited
+	// and been reused by an unrelated process since
+	table, err := readProcessTable()
+	if err != nil {
+		return err
+	}
+	if !slices.Contains(descendants(table, job.PID), pid) {
+		return fmt.Errorf("process %d has exited", pid)
+	}
+
+	if job.PID == pid {
+		if err := b.jobs.Signal(job.ID, sig); err != nil {
+			return fmt.Errorf("failed to signal %s: %v", job.Title, err)
+		}
+	} else {
+		process, err := os.FindProcess(pid)
+		if err != nil {
+			return fmt.Errorf("failed to find process %d: %v", pid, err)
+		}
+		if err := process.Signal(sig); err != nil {
+			return fmt.Errorf("failed to signal process %d: %v", pid, err)
+		}
+	}
+
+	b.jobs.Log(target.Job, fmt.Sprintf("Sent %s to %d (%s)", signalName(sig), pid, truncate(target.Command, 60)))
+	select {
+	case b.resample <- struct{}{}:
+	default:
+	}
+	return nil
+}
+
+func signalName(sig syscall.Signal) string {
+	switch sig {
+	case syscall.SIGINT:
+		return "SIGINT"
+	case syscall.SIGTERM:
+		return "SIGTERM"
+	case syscall.SIGKILL:
+		return "SIGKILL"
+	}
+	return sig.String()
+}
+
+// processRow is a process placed in its job's tree
+type processRow struct {
+	ProcessInfo
+	Depth int
+}
+
+// processTree orders the sampled processes job by job, each child below its parent
+func processTree(processes []ProcessInfo) []processRow {
+	byPID := make(map[int]bool)
+	children := make(map[int][]ProcessInfo)
+	for _, process := range processes {
+		byPID[process.PID] = true
+		children[process.PPID] = append(children[process.PPID], process)
+	}
+
+	var rows []processRow
+	var walk func(process ProcessInfo, depth int)
+	walk = func(process ProcessInfo, depth int) {
+		rows = append(rows, processRow{ProcessInfo: process, Depth: depth})
+		for _, child := range children[process.PID] {
+			walk(child, depth+1)
+		}
+	}
+	// The sampler lists each job's root before its descendants, so roots keep the job order
+	for _, process := range processes {
+		if !byPID[process.PPID] {
+			walk(process, 0)
+		}
+	}
+	return rows
+}
+
+// selectedProcess returns the index of the selected PID in rows, falling back to the first row
+func (m Model) selectedProcess(rows []processRow) int {
+	for i, row := range rows {
+		if row.PID == m.processPID {
+			return i
+		}
+	}
+	return 0
+}
+
+func (m Model) openProcesses() Model {
+	m.state = StateProcesses
+	m.processPID = 0
+	m.statusMessage = ""
+	return m
+}
+
+func (m Model) handleProcessesKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	rows := processTree(m.backend.GetSystemSample().Processes)
+	index := m.selectedProcess(rows)
+
+	switch key := msg.String(); key {
+	case "ctrl+c", "q":
+		m.backend.Cleanup()
+		return m, tea.Quit
+	case "esc":
+		m.state = StateMenu
+	case "up", "k":
+		if index > 0 {
+			m.processPID = rows[index-1].PID
+		}
+	case "down", "j":
+		if index < len(rows)-1 {
+			m.processPID = rows[index+1].PID
+		}
+	case "i", "t", "K":
+		if len(rows) == 0 {
+			break
+		}
+		row := rows[index]
+		if err := m.backend.SignalProcess(row.PID, processSignals[key]); err != nil {
+			m.statusMessage = fmt.Sprintf("❌ %v", err)
+		} else {
+			m.statusMessage = fmt.Sprintf("📨 Sent %s to %d", signalName(processSignals[key]), row.PID)
+		}
+	}
+	return m, nil
+}
+
+func (m Model) renderProcesses() string {
+	s := "\n"
+	s += titleStyle.Render("🌳 PROCESSES") + "\n\n"
+
+	if !m.backend.procAvailable {
+		s += detailStyle.Render("The process tree is read from /proc and is only available on Linux") + "\n"
+		s += "\n" + helpStyle.Render("esc: return to menu") + "\n"
+		return s
+	}
+
+	sample := m.backend.GetSystemSample()
+	rows := processTree(sample.Processes)
+	if len(rows) == 0 {
+		s += detailStyle.Render("No jobs running — start a build, watch or script to see its processes") + "\n"
+	} else {
+		width := m.width
+		if width <= 0 {
+			width = 100
+		}
+		s += statsStyle.Render(fmt.Sprintf("  %7s %6s %9s %8s  %s", "PID", "CPU", "RSS", "Uptime", "Command")) + "\n"
+
+		index := m.selectedProcess(rows)
+		job := ""
+		for i, row := range rows {
+			if row.Job != job {
+				job = row.Job
+				title := job
+				if info, ok := m.backend.jobs.Get(job); ok {
+					title = info.Title
+				}
+				s += infoStyle.Render(title) + "\n"
+			}
+			uptime := sample.At.Sub(row.StartedAt).Round(time.Second)
+			command := strings.Repeat("  ", row.Depth) + row.Command
+			line := fmt.Sprintf("%7d %5.0f%% %9s %8s  ", row.PID, row.CPU, formatBytes(row.RSS), uptime)
+			line += truncate(command, max(width-len(line)-4, 20))
+			if i == index {
+				s += selectedStyle.Render("> "+line) + "\n"
+			} else {
+				s += normalStyle.Render("  "+line) + "\n"
+			}
+		}
+		selected := rows[index]
+		s += "\n" + detailStyle.Render(fmt.Sprintf("PID %d • parent %d • %s • %d open files", selected.PID, selected.PPID, selected.Status, selected.OpenFiles)) + "\n"
+	}
+
+	if m.statusMessage != "" {
+		s += "\n" + infoStyle.Render(m.statusMessage) + "\n"
+	}
+
+	s += "\n" + helpStyle.Render("↑/↓: select • i: SIGINT • t: SIGTERM • K: SIGKILL • esc: return to menu") + "\n"
+	return s
+}
@@ -0,0 +1,184 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// procRoot is where the kernel exposes process information; only Linux has it
+const procRoot = "/proc"
+
+// clockTicks is USER_HZ, the unit of the CPU times in /proc/<pid>/stat. It is 100 on every Linux platform Go supports.
+const clockTicks = 100
+
+// procStat is the part of /proc/<pid>/stat the sampler uses
+type procStat struct {
+	PID        int
+	PPID       int
+	Comm       string
+	State      string
+	Ticks      uint64 // user + system CPU time in clock ticks
+	StartTicks uint64 // start time in clock ticks after boot
+	RSS        int64  // resident set size in bytes
+}
+
+// procAvailable reports whether /proc can be read on this system
+func procAvailable() bool {
+	_, err := os.Stat(filepath.Join(procRoot, "self", "stat"))
+	return err == nil
+}
+
+// readProcessTable reads the stat file of every process, keyed by PID
+func readProcessTable() (map[int]procStat, error) {
+	entries, err := os.ReadDir(procRoot)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %v", procRoot, err)
+	}
+
+	table := make(map[int]procStat)
+	for _, entry := range entries {
+		pid, err := strconv.Atoi(entry.Name())
+		if err != nil {
+			continue
+		}
+		// Processes can exit between listing and reading
+		if stat, err := readProcStat(pid); err == nil {
+			table[pid] = stat
+		}
+	}
+	return table, nil
+}
+
+func readProcStat(pid int) (procStat, error) {
+	data, err := os.ReadFile(filepath.Join(procRoot, strconv.Itoa(pid), "stat"))
+	if err != nil {
+		return procStat{}, err
+	}
+
+	// The command name is in parentheses and may itself contain spaces and parentheses
+	line := string(data)
+	open, end := strings.IndexByte(line, '('), strings.LastIndexByte(line, ')')
+	if open < 0 || end < open {
+		return procStat{}, fmt.Errorf("malformed stat for pid %d", pid)
+	}
+	fields := strings.Fields(line[end+1:])
+	if len(fields) < 22 {
+		return procStat{}, fmt.Errorf("short stat for pid %d", pid)
+	}
+
+	// fields[0] is field 3 of proc(5)
+	field := func(n int) uint64 {
+		value, _ := strconv.ParseUint(fields[n-3], 10, 64)
+		return value
+	}
+	return procStat{
+		PID:        pid,
+		PPID:       int(field(4)),
+		Comm:       line[open+1 : end],
+		State:      fields[0],
+		Ticks:      field(14) + field(15),
+		StartTicks: field(22),
+		RSS:        int64(field(24)) * int64(os.Getpagesize()),
+	}, nil
+}
+
+// descendants returns root and every process below it, parents before children
+func descendants(table map[int]procStat, root int) []int {
+	children := make(map[int][]int)
+	for pid, stat := range table {
+		children[stat.PPID] = append(children[stat.PPID], pid)
+	}
+
+	var pids []int
+	queue := []int{root}
+	for len(queue) > 0 {
+		pid := queue[0]
+		queue = queue[1:]
+		if _, ok := table[pid]; !ok {
+			continue
+		}
+		pids = append(pids, pid)
+		sort.Ints(children[pid])
+		queue = append(queue, children[pid]...)
+	}
+	return pids
+}
+
+// readCmdline returns the full command line, falling back to the command name for kernel threads and zombies
+func readCmdline(pid int, comm string) string {
+	data, err := os.ReadFile(filepath.Join(procRoot, strconv.Itoa(pid), "cmdline"))
+	if err != nil || len(data) == 0 {
+		return "[" + comm + "]"
+	}
+	return strings.TrimSpace(strings.ReplaceAll(string(data), "\x00", " "))
+}
+
+// countOpenFiles counts the file descriptors of a process
+func countOpenFiles(pid int) int {
+	entries, err := os.ReadDir(filepath.Join(procRoot, strconv.Itoa(pid), "fd"))
+	if err != nil {
+		return 0
+	}
+	return len(entries)
+}
+
+// readIOBytes returns the bytes a process has read from and written to storage
+func readIOBytes(pid int) uint64 {
+	file, err := os.Open(filepath.Join(procRoot, strconv.Itoa(pid), "io"))
+	if err != nil {
+		return 0
+	}
+	defer file.Close()
+
+	var total uint64
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		key, value, ok := strings.Cut(scanner.Text(), ":")
+		if ok && (key == "read_bytes" || key == "write_bytes") {
+			n, _ := strconv.ParseUint(strings.TrimSpace(value), 10, 64)
+			total += n
+		}
+	}
+	return total
+}
+
+// readLoadAverage returns the one-minute system load average
+func readLoadAverage() float64 {
+	data, err := os.ReadFile(filepath.Join(procRoot, "loadavg"))
+	if err != nil {
+		return 0
+	}
+	fields := strings.Fields(string(data))
+	if len(fields) == 0 {
+		return 0
+	}
+	load, _ := strconv.ParseFloat(fields[0], 64)
+	return load
+}
+
+// readBootTime returns when the system booted, which process start ticks are relative to
+func readBootTime() (time.Time, error) {
+	file, err := os.Open(filepath.Join(procRoot, "stat"))
+	if err != nil {
+		return time.Time{}, err
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		if value, ok := strings.CutPrefix(scanner.Text(), "btime "); ok {
+			seconds, err := strconv.ParseInt(strings.TrimSpace(value), 10, 64)
+			if err != nil {
+				return time.Time{}, err
+			}
+			return time.Unix(seconds, 0), nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("btime missing from %s/stat", procRoot)
+}
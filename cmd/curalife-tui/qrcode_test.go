@@ -0,0 +1,107 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// The golden matrices in testdata/qr were generated with Kazuhiko Arase's
+// QR Code generator (the one qrcode-terminal vendors) at level M, one row per
+// line with "#" for dark modules.
+func TestEncodeQRVersion(t *testing.T) {
+	tests := []struct {
+		text    string
+		version int
+		mask    int
+	}{
+		{"HELLO WORLD", 1, 0},
+		{"http://127.0.0.1:9292", 2, 3},
+		{"https://curalife.myshopify.com/?preview_theme_id=123456789012", 5, 5},
+		// Version 7 and up carry version information
+		{"https://curalife.myshopify.com/products/curalin?preview_theme_id=139876543210&_fd=0&_sc=1", 7, 2},
+		// Version 10 has a 16-bit character count and two block groups
+		{"https://curalife-commerce.myshopify.com/collections/all-products/curalin-90?preview_theme_id=139876543210&_fd=0&_sc=1&pb=0&variant=40123456789012", 10, 6},
+	}
+	for _, test := range tests {
+		name := fmt.Sprintf("v%d-mask%d", test.version, test.mask)
+		t.Run(name, func(t *testing.T) {
+			golden, err := os.ReadFile(filepath.Join("testdata", "qr", name+".txt"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			qr, ok := encodeQRVersion([]byte(test.text), test.version, test.mask)
+			if !ok {
+				t.Fatalf("%d bytes don't fit version %d", len(test.text), test.version)
+			}
+			want := strings.Split(strings.TrimSpace(string(golden)), "\n")
+			if len(want) != qr.size {
+				t.Fatalf("got size %d, want %d", qr.size, len(want))
+			}
+			for y, row := range want {
+				var got strings.Builder
+				for x := 0; x < qr.size; x++ {
+					if qr.modules[y][x] {
+						got.WriteByte('#')
+					} else {
+						got.WriteByte('.')
+					}
+				}
+				if got.String() != row {
+					t.Errorf("row %d:\n got %s\nwant %s", y, got.String(), row)
+				}
+			}
+		})
+	}
+}
+
+func TestEncodeQRPicksSmallestVersion(t *testing.T) {
+	tests := []struct {
+		length int
+		size   int
+	}{
+		{14, 21},  // version 1 holds 14 bytes at level M
+		{15, 25},  // version 2
+		{213, 57}, // version 10 holds 213
+	}
+	for _, test := range tests {
+		qr, err := encodeQR(strings.Repeat("a", test.length))
+		if err != nil {
+			t.Fatalf("%d bytes: %v", test.length, err)
+		}
+		if qr.size != test.size {
+			t.Errorf("%d bytes: got size %d, want %d", test.length, qr.size, test.size)
+		}
+	}
+	if _, err := encodeQR(strings.Repeat("a", 214)); err == nil {
+		t.Error("expected an error for 214 bytes")
+	}
+}
+
+func TestRenderQuietZone(t *testing.T) {
+	qr, err := encodeQR("HELLO WORLD")
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSuffix(qr.Render(), "\n"), "\n")
+	// 21 modules plus four on each side, two module rows per line
+	if len(lines) != 15 {
+		t.Fatalf("got %d lines, want 15", len(lines))
+	}
+	for _, line := range lines[:2] {
+		if line != strings.Repeat("█", 29) {
+			t.Errorf("quiet zone line %q is not all light", line)
+		}
+	}
+	// The odd module row count leaves the last line half empty
+	if last := lines[len(lines)-1]; last != strings.Repeat("▀", 29) {
+		t.Errorf("last line %q is not all light", last)
+	}
+	for _, line := range lines[:len(lines)-1] {
+		if !strings.HasPrefix(line, "████") || !strings.HasSuffix(line, "████") {
+			t.Errorf("line %q lacks a four module quiet zone", line)
+		}
+	}
+}
@@ -0,0 +1,117 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"sort"
+)
+
+// Regression detection settings. The threshold matches regressionThreshold in build-analytics.js.
+const (
+	regressionWindow     = 10
+	regressionThreshold  = 0.15
+	regressionMinSamples = 3
+	// Robust z-score above which a slowdown is treated as more than noise
+	regressionMinZScore = 3.0
+	// Smallest spread assumed for the baseline, as a share of its median, so
+	// identical past builds do not make every millisecond significant
+	regressionNoiseFloor = 0.02
+)
+
+// RegressionResult compares one build's duration with the median of recent builds of the same profile
+type RegressionResult struct {
+	BuildID  string  `json:"buildId"`
+	Profile  string  `json:"profile"`
+	Duration int64   `json:"durationMs"`
+	Baseline float64 `json:"baselineMedianMs"`
+	Samples  int     `json:"baselineSamples"`
+	Change   float64 `json:"change"`
+	ZScore   float64 `json:"zScore"`
+	// Regressed is set when the build is both over the threshold and statistically significant
+	Regressed bool `json:"regressed"`
+}
+
+// Summary describes the result in one line
+func (r RegressionResult) Summary() string {
+	return fmt.Sprintf("%s vs median %s of the last %d %q builds (%+.0f%%)",
+		formatMillis(r.Duration), formatMillis(int64(r.Baseline)), r.Samples, r.Profile, r.Change*100)
+}
+
+// detectRegression compares current with up to window earlier successful builds
+// of the same profile. ok is false when there is not enough history for a baseline.
+func detectRegression(history []BuildReport, current BuildReport, window int) (result RegressionResult, ok bool) {
+	var baseline []float64
+	// History is newest first, so the first matches are the most recent builds
+	for _, report := range history {
+		if len(baseline) == window {
+			break
+		}
+		if report.Metrics.BuildID == current.Metrics.BuildID || report.Metrics.Profile != current.Metrics.Profile ||
+			report.Outcome() != "success" || !report.Started().Before(current.Started()) {
+			continue
+		}
+		baseline = append(baseline, float64(report.Metrics.BuildTime))
+	}
+	if len(baseline) < regressionMinSamples {
+		return RegressionResult{}, false
+	}
+
+	duration := float64(current.Metrics.BuildTime)
+	median := medianOf(baseline)
+	deviations := make([]float64, len(baseline))
+	for i, value := range baseline {
+		deviations[i] = math.Abs(value - median)
+	}
+	// 1.4826 scales the median absolute deviation to a standard deviation for normal data
+	spread := math.Max(1.4826*medianOf(deviations), median*regressionNoiseFloor)
+
+	result = RegressionResult{
+		BuildID:  current.Metrics.BuildID,
+		Profile:  current.Metrics.Profile,
+		Duration: current.Metrics.BuildTime,
+		Baseline: median,
+		Samples:  len(baseline),
+	}
+	if median > 0 {
+		result.Change = (duration - median) / median
+		result.ZScore = (duration - median) / spread
+	}
+	result.Regressed = result.Change > regressionThreshold && result.ZScore > regressionMinZScore
+	return result, true
+}
+
+// checkBuildRegression loads the history and checks the build with the given report ID
+func checkBuildRegression(buildID string) (RegressionResult, bool, error) {
+	history, err := loadBuildHistory()
+	if err != nil {
+		return RegressionResult{}, false, err
+	}
+	for _, report := range history {
+		if report.Metrics.BuildID == buildID {
+			result, ok := detectRegression(history, report, regressionWindow)
+			return result, ok, nil
+		}
+	}
+	return RegressionResult{}, false, fmt.Errorf("build %s is not in the history", buildID)
+}
+
+// historyRegressions checks every build in the history against the builds before it
+func historyRegressions(history []BuildReport) map[string]RegressionResult {
+	regressions := make(map[string]RegressionResult)
+	for _, report := range history {
+		if result, ok := detectRegression(history, report, regressionWindow); ok && result.Regressed {
+			regressions[report.Metrics.BuildID] = result
+		}
+	}
+	return regressions
+}
+
+func medianOf(values []float64) float64 {
+	sorted := append([]float64(nil), values...)
+	sort.Float64s(sorted)
+	middle := len(sorted) / 2
+	if len(sorted)%2 == 0 {
+		return (sorted[middle-1] + sorted[middle]) / 2
+	}
+	return sorted[middle]
+}
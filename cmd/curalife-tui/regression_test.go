@@ -0,0 +1,109 @@
+package main
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+// historyReport is a finished build of the test history, started minutesAgo before now
+func historyReport(id, profile, status string, minutesAgo int, buildTime int64) BuildReport {
+	start := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC).Add(-time.Duration(minutesAgo) * time.Minute)
+	return BuildReport{Metrics: BuildMetrics{
+		BuildID:   id,
+		Profile:   profile,
+		Status:    status,
+		StartTime: start.UnixMilli(),
+		BuildTime: buildTime,
+	}}
+}
+
+// steadyHistory is newest first, like build-history.json
+func steadyHistory(durations ...int64) []BuildReport {
+	var history []BuildReport
+	for i, duration := range durations {
+		history = append(history, historyReport(fmt.Sprintf("b%d", i), "default", "success", (i+1)*10, duration))
+	}
+	return history
+}
+
+func TestDetectRegression(t *testing.T) {
+	tests := []struct {
+		name          string
+		history       []BuildReport
+		current       BuildReport
+		window        int
+		wantOK        bool
+		wantRegressed bool
+		wantSamples   int
+	}{
+		{
+			name:    "too little history for a baseline",
+			history: steadyHistory(1000, 1000),
+			current: historyReport("now", "default", "success", 0, 5000),
+		},
+		{
+			name:          "clear slowdown over a steady baseline",
+			history:       steadyHistory(1000, 1000, 1010, 990, 1000),
+			current:       historyReport("now", "default", "success", 0, 1500),
+			wantOK:        true,
+			wantRegressed: true,
+			wantSamples:   5,
+		},
+		{
+			name:        "slowdown under the threshold",
+			history:     steadyHistory(1000, 1000, 1010, 990, 1000),
+			current:     historyReport("now", "default", "success", 0, 1100),
+			wantOK:      true,
+			wantSamples: 5,
+		},
+		{
+			name:        "over the threshold but within the noise of the baseline",
+			history:     steadyHistory(1000, 1600, 700, 1500, 800),
+			current:     historyReport("now", "default", "success", 0, 1300),
+			wantOK:      true,
+			wantSamples: 5,
+		},
+		{
+			name: "other profiles, failed and later builds are not in the baseline",
+			history: []BuildReport{
+				historyReport("later", "default", "success", -5, 1000),
+				historyReport("report", "report", "success", 10, 1000),
+				historyReport("failed", "default", "failed", 20, 1000),
+				historyReport("ok1", "default", "success", 30, 1000),
+				historyReport("ok2", "default", "success", 40, 1000),
+			},
+			current: historyReport("now", "default", "success", 0, 5000),
+		},
+		{
+			name:          "only the window of most recent builds counts",
+			history:       steadyHistory(1000, 1000, 1000, 1000, 5000, 5000, 5000, 5000),
+			current:       historyReport("now", "default", "success", 0, 1500),
+			window:        4,
+			wantOK:        true,
+			wantRegressed: true,
+			wantSamples:   4,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			window := regressionWindow
+			if test.window > 0 {
+				window = test.window
+			}
+			result, ok := detectRegression(test.history, test.current, window)
+			if ok != test.wantOK {
+				t.Fatalf("ok = %v, want %v", ok, test.wantOK)
+			}
+			if !ok {
+				return
+			}
+			if result.Regressed != test.wantRegressed {
+				t.Errorf("regressed = %v, want %v (%s, z %.1f)", result.Regressed, test.wantRegressed, result.Summary(), result.ZScore)
+			}
+			if result.Samples != test.wantSamples {
+				t.Errorf("samples = %d, want %d", result.Samples, test.wantSamples)
+			}
+		})
+	}
+}
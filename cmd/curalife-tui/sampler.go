@@ -0,0 +1,221 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// Process sampling interval, configurable as sample_interval_ms in settings.json
+const (
+	defaultSampleInterval = 2 * time.Second
+	minSampleInterval     = 250 * time.Millisecond
+)
+
+// sampleInterval returns the configured sampling interval, kept above a sane minimum
+func (s Settings) sampleInterval() time.Duration {
+	if s.SampleInterval <= 0 {
+		return defaultSampleInterval
+	}
+	return max(time.Duration(s.SampleInterval)*time.Millisecond, minSampleInterval)
+}
+
+// SystemSample is one reading of the process trees under the running jobs
+type SystemSample struct {
+	At        time.Time
+	Processes []ProcessInfo
+	// Jobs holds the totals of each job's tree, by job ID
+	Jobs  map[string]SystemData
+	Total SystemData
+}
+
+// ProcessSampler turns successive /proc readings into CPU and I/O rates
+type ProcessSampler struct {
+	bootTime  time.Time
+	sampledAt time.Time
+	ticks     map[int]uint64
+	ioBytes   map[int]uint64
+}
+
+// NewProcessSampler prepares a sampler; it fails where /proc is not available
+func NewProcessSampler() (*ProcessSampler, error) {
+	bootTime, err := readBootTime()
+	if err != nil {
+		return nil, err
+	}
+	return &ProcessSampler{bootTime: bootTime, ticks: map[int]uint64{}, ioBytes: map[int]uint64{}}, nil
+}
+
+// Sample reads every process below the given root PIDs, which map to their job IDs
+func (s *ProcessSampler) Sample(roots map[int]string, now time.Time) (SystemSample, error) {
+	table, err := readProcessTable()
+	if err != nil {
+		return SystemSample{}, err
+	}
+
+	sample := SystemSample{At: now, Jobs: make(map[string]SystemData)}
+	elapsed := now.Sub(s.sampledAt).Seconds()
+	if s.sampledAt.IsZero() {
+		elapsed = 0
+	}
+	ticks, ioBytes := make(map[int]uint64), make(map[int]uint64)
+
+	rootPIDs := make([]int, 0, len(roots))
+	for pid := range roots {
+		rootPIDs = append(rootPIDs, pid)
+	}
+	sort.Ints(rootPIDs)
+
+	seen := make(map[int]bool)
+	for _, root := range rootPIDs {
+		job := roots[root]
+		totals := SystemData{}
+		for _, pid := range descendants(table, root) {
+			if seen[pid] {
+				continue
+			}
+			seen[pid] = true
+			stat := table[pid]
+			started := s.bootTime.Add(time.Duration(stat.StartTicks) * time.Second / clockTicks)
+
+			// CPU over the last interval, or the average since start for processes not seen before
+			var cpu float64
+			if previous, ok := s.ticks[pid]; ok && elapsed > 0 && stat.Ticks >= previous {
+				cpu = float64(stat.Ticks-previous) / clockTicks / elapsed * 100
+			} else if lifetime := now.Sub(started).Seconds(); lifetime > 0 {
+				cpu = float64(stat.Ticks) / clockTicks / lifetime * 100
+			}
+			ticks[pid] = stat.Ticks
+
+			io := readIOBytes(pid)
+			if previous, ok := s.ioBytes[pid]; ok && elapsed > 0 && io >= previous {
+				totals.DiskUsage += float64(io-previous) / (1024 * 1024) / elapsed
+			}
+			ioBytes[pid] = io
+
+			info := ProcessInfo{
+				PID:       pid,
+				PPID:      stat.PPID,
+				Job:       job,
+				Command:   readCmdline(pid, stat.Comm),
+				Status:    procStateName(stat.State),
+				CPU:       cpu,
+				RSS:       stat.RSS,
+				OpenFiles: countOpenFiles(pid),
+				StartedAt: started,
+			}
+			sample.Processes = append(sample.Processes, info)
+
+			totals.CPUUsage += info.CPU
+			totals.MemoryUsage += float64(info.RSS) / (1024 * 1024)
+			totals.OpenFiles += info.OpenFiles
+			totals.ProcessCount++
+		}
+		sample.Jobs[job] = totals
+		sample.Total = sample.Total.add(totals)
+	}
+	sample.Total.SystemLoad = readLoadAverage()
+
+	s.ticks, s.ioBytes, s.sampledAt = ticks, ioBytes, now
+	return sample, nil
+}
+
+// add sums two readings; the load average is system-wide and not summed
+func (d SystemData) add(other SystemData) SystemData {
+	d.CPUUsage += other.CPUUsage
+	d.MemoryUsage += other.MemoryUsage
+	d.DiskUsage += other.DiskUsage
+	d.OpenFiles += other.OpenFiles
+	d.ProcessCount += other.ProcessCount
+	return d
+}
+
+// peak keeps the highest value of every field
+func (d SystemData) peak(other SystemData) SystemData {
+	return SystemData{
+		CPUUsage:     max(d.CPUUsage, other.CPUUsage),
+		MemoryUsage:  max(d.MemoryUsage, other.MemoryUsage),
+		DiskUsage:    max(d.DiskUsage, other.DiskUsage),
+		OpenFiles:    max(d.OpenFiles, other.OpenFiles),
+		ProcessCount: max(d.ProcessCount, other.ProcessCount),
+		SystemLoad:   max(d.SystemLoad, other.SystemLoad),
+	}
+}
+
+// Summary describes a reading in one line
+func (d SystemData) Summary() string {
+	return fmt.Sprintf("CPU %.0f%% • RSS %.0f MB • disk %.1f MB/s • %d files • %d processes",
+		d.CPUUsage, d.MemoryUsage, d.DiskUsage, d.OpenFiles, d.ProcessCount)
+}
+
+func procStateName(state string) string {
+	switch state {
+	case "R":
+		return "running"
+	case "S":
+		return "sleeping"
+	case "D":
+		return "disk wait"
+	case "Z":
+		return "zombie"
+	case "T", "t":
+		return "stopped"
+	case "I":
+		return "idle"
+	}
+	return state
+}
+
+// runSampler samples the job process trees at the configured interval until Cleanup
+func (b *Backend) runSampler(interval time.Duration) {
+	sampler, err := NewProcessSampler()
+	if err != nil {
+		// No /proc on this platform; the system numbers stay empty
+		return
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-b.stopSampler:
+			return
+		case now := <-ticker.C:
+			b.sampleProcesses(sampler, now)
+		case <-b.resample:
+			// Give a signalled process a moment to exit
+			time.Sleep(100 * time.Millisecond)
+			b.sampleProcesses(sampler, time.Now())
+		}
+	}
+}
+
+func (b *Backend) sampleProcesses(sampler *ProcessSampler, now time.Time) {
+	roots := make(map[int]string)
+	for _, job := range b.jobs.List() {
+		if job.State == JobRunning && job.PID > 0 {
+			roots[job.PID] = job.ID
+		}
+	}
+	sample, err := sampler.Sample(roots, now)
+	if err != nil {
+		return
+	}
+
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	b.system = sample
+	b.recordWatchRSS(sample)
+	// Peaks only count the build's own process tree
+	if build, ok := sample.Jobs["build"]; ok && b.buildStatus.IsRunning {
+		build.SystemLoad = sample.Total.SystemLoad
+		b.buildStatus.Peaks = b.buildStatus.Peaks.peak(build)
+	}
+}
+
+// GetSystemSample returns the latest process sample
+func (b *Backend) GetSystemSample() SystemSample {
+	b.mutex.RLock()
+	defer b.mutex.RUnlock()
+	return b.system
+}
@@ -0,0 +1,411 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// settingFields lists the input setting types the theme editor accepts and
+// the fields each needs besides id and label
+var settingFields = map[string][]string{
+	"article": nil, "blog": nil, "checkbox": nil, "collection": nil, "collection_list": nil,
+	"color": nil, "color_background": nil, "color_scheme": nil, "color_scheme_group": nil,
+	"font_picker": {"default"}, "html": nil, "image_picker": nil, "inline_richtext": nil,
+	"link_list": nil, "liquid": nil, "metaobject": {"metaobject_type"}, "metaobject_list": {"metaobject_type"},
+	"number": nil, "page": nil, "product": nil, "product_list": nil, "radio": {"options"},
+	"range": {"min", "max", "default"}, "richtext": nil, "select": {"options"}, "text": nil,
+	"text_alignment": nil, "textarea": nil, "url": nil, "video": nil, "video_url": {"accept"},
+}
+
+// resourceSettings are the setting types whose value is picked in the editor
+// and can't have a default, link_list aside
+var resourceSettings = map[string]bool{
+	"article": true, "blog": true, "collection": true, "collection_list": true, "image_picker": true,
+	"metaobject": true, "metaobject_list": true, "page": true, "product": true, "product_list": true, "video": true,
+}
+
+var hexColorPattern = regexp.MustCompile(`^#(?:[0-9a-fA-F]{3}|[0-9a-fA-F]{6}|[0-9a-fA-F]{8})$`)
+
+// schemaObject is a JSON object from a schema, with its values left raw
+type schemaObject map[string]json.RawMessage
+
+// str returns a string field, or "" when it is missing or not a string
+func (o schemaObject) str(key string) string {
+	var value string
+	json.Unmarshal(o[key], &value)
+	return value
+}
+
+// objects decodes a field holding an array of objects
+func (o schemaObject) objects(key string) []schemaObject {
+	var values []schemaObject
+	json.Unmarshal(o[key], &values)
+	return values
+}
+
+// schemaValidator collects the findings for the schema of one section file
+type schemaValidator struct {
+	file        string
+	body        string
+	line        int // line the schema body starts on
+	diagnostics []Diagnostic
+	// blockFiles are the theme blocks in src/liquid/blocks, for "@theme" presets
+	blockFiles map[string]bool
+}
+
+// lineOf finds the line of the nth JSON "key": "value" pair in the schema body,
+// or the schema tag itself when it can't be found
+func (v *schemaValidator) lineOf(key, value string, nth int) int {
+	pattern := regexp.MustCompile(`"` + regexp.QuoteMeta(key) + `"\s*:\s*"` + regexp.QuoteMeta(value) + `"`)
+	matches := pattern.FindAllStringIndex(v.body, -1)
+	if nth >= len(matches) {
+		return v.line
+	}
+	return v.line + strings.Count(v.body[:matches[nth][0]], "\n")
+}
+
+func (v *schemaValidator) report(severity, check string, line int, format string, args ...interface{}) {
+	v.diagnostics = append(v.diagnostics, Diagnostic{
+		Severity: severity,
+		Tool:     "schema",
+		Check:    check,
+		File:     v.file,
+		Line:     line,
+		Message:  fmt.Sprintf(format, args...),
+	})
+}
+
+// validateSettings checks one settings array; owner names it in messages
+func (v *schemaValidator) validateSettings(settings []schemaObject, owner string) map[string]bool {
+	ids := make(map[string]bool)
+	seen := make(map[string]int)
+	for i, setting := range settings {
+		kind, id := setting.str("type"), setting.str("id")
+		line := v.line
+		if id != "" {
+			line = v.lineOf("id", id, seen[id])
+			seen[id]++
+		} else if kind != "" {
+			line = v.lineOf("type", kind, 0)
+		}
+		name := fmt.Sprintf("%s setting %d", owner, i+1)
+		if id != "" {
+			name = fmt.Sprintf("%s setting %q", owner, id)
+		}
+
+		if kind == "header" || kind == "paragraph" {
+			if _, ok := setting["content"]; !ok {
+				v.report("error", "MissingField", line, "%s (%s) has no content", name, kind)
+			}
+			continue
+		}
+		required, ok := settingFields[kind]
+		if !ok {
+			if kind == "" {
+				v.report("error", "MissingField", line, "%s has no type", name)
+			} else {
+				v.report("error", "UnknownSettingType", line, "%s has type %q, which isn't a setting type", name, kind)
+			}
+			continue
+		}
+		for _, field := range append([]string{"id", "label"}, required...) {
+			if _, ok := setting[field]; !ok {
+				v.report("error", "MissingField", line, "%s (%s) needs %q", name, kind, field)
+			}
+		}
+		if id != "" {
+			if ids[id] {
+				v.report("error", "DuplicateID", line, "%s is defined more than once", name)
+			}
+			ids[id] = true
+		}
+		if value, ok := setting["default"]; ok {
+			if problem := v.defaultProblem(setting, kind, value); problem != "" {
+				severity := "error"
+				if resourceSettings[kind] {
+					severity = "warning"
+				}
+				v.report(severity, "InvalidDefault", line, "%s: %s", name, problem)
+			}
+		}
+	}
+	return ids
+}
+
+// defaultProblem describes why a default value doesn't fit its setting type
+func (v *schemaValidator) defaultProblem(setting schemaObject, kind string, value json.RawMessage) string {
+	var decoded interface{}
+	if err := json.Unmarshal(value, &decoded); err != nil {
+		return "default isn't valid JSON"
+	}
+	switch kind {
+	case "checkbox":
+		if _, ok := decoded.(bool); !ok {
+			return fmt.Sprintf("default %s should be true or false", value)
+		}
+	case "number", "range":
+		number, ok := decoded.(float64)
+		if !ok {
+			return fmt.Sprintf("default %s should be a number", value)
+		}
+		if kind == "range" {
+			var low, high float64
+			if json.Unmarshal(setting["min"], &low) == nil && json.Unmarshal(setting["max"], &high) == nil &&
+				(number < low || number > high) {
+				return fmt.Sprintf("default %v is outside %v-%v", number, low, high)
+			}
+		}
+	case "select", "radio":
+		text, ok := decoded.(string)
+		if !ok {
+			return fmt.Sprintf("default %s should be one of the option values", value)
+		}
+		for _, option := range setting.objects("options") {
+			if option.str("value") == text {
+				return ""
+			}
+		}
+		return fmt.Sprintf("default %q isn't one of the option values", text)
+	case "color":
+		text, ok := decoded.(string)
+		if !ok || (!hexColorPattern.MatchString(text) && !strings.HasPrefix(text, "rgb") && text != "transparent") {
+			return fmt.Sprintf("default %s isn't a color", value)
+		}
+	case "text_alignment":
+		if text, _ := decoded.(string); text != "left" && text != "center" && text != "right" {
+			return fmt.Sprintf("default %s should be left, center or right", value)
+		}
+	default:
+		if resourceSettings[kind] {
+			return fmt.Sprintf("%s settings can't have a default", kind)
+		}
+		if _, ok := decoded.(string); !ok {
+			return fmt.Sprintf("default %s should be a string", value)
+		}
+	}
+	return ""
+}
+
+// validate checks the schema of one section
+func (v *schemaValidator) validate() {
+	var schema schemaObject
+	if err := json.Unmarshal([]byte(v.body), &schema); err != nil {
+		line := v.line
+		if syntaxErr, ok := err.(*json.SyntaxError); ok && int(syntaxErr.Offset) <= len(v.body) {
+			line += strings.Count(v.body[:syntaxErr.Offset], "\n")
+		}
+		v.report("error", "SchemaJSON", line, "Invalid schema JSON: %v", err)
+		return
+	}
+	if _, ok := schema["name"]; !ok {
+		v.report("error", "MissingField", v.line, "Section schema has no name")
+	}
+
+	settingIDs := v.validateSettings(schema.objects("settings"), "Section")
+
+	blockSettings := make(map[string]map[string]bool)
+	themeBlocks := false
+	seen := make(map[string]int)
+	for _, block := range schema.objects("blocks") {
+		kind := block.str("type")
+		line := v.lineOf("type", kind, seen[kind])
+		seen[kind]++
+		switch {
+		case kind == "":
+			v.report("error", "MissingField", v.line, "A block has no type")
+			continue
+		case kind == "@theme":
+			themeBlocks = true
+			continue
+		case strings.HasPrefix(kind, "@"):
+			continue
+		}
+		if _, ok := blockSettings[kind]; ok {
+			v.report("error", "DuplicateID", line, "Block type %q is defined more than once", kind)
+			continue
+		}
+		if _, ok := block["name"]; !ok {
+			v.report("error", "MissingField", line, "Block %q has no name", kind)
+		}
+		blockSettings[kind] = v.validateSettings(block.objects("settings"), fmt.Sprintf("Block %q", kind))
+	}
+
+	for i, preset := range schema.objects("presets") {
+		name := preset.str("name")
+		line := v.line
+		if name != "" {
+			line = v.lineOf("name", name, 0)
+		} else {
+			v.report("error", "MissingField", line, "Preset %d has no name", i+1)
+		}
+		var settings map[string]json.RawMessage
+		json.Unmarshal(preset["settings"], &settings)
+		for _, id := range sortedKeys(settings) {
+			if !settingIDs[id] {
+				v.report("error", "PresetReference", line, "Preset %q sets %q, which isn't a section setting", name, id)
+			}
+		}
+		for _, block := range presetBlocks(preset["blocks"]) {
+			kind := block.str("type")
+			ids, ok := blockSettings[kind]
+			if !ok {
+				if !(themeBlocks && v.blockFiles[kind]) {
+					v.report("error", "PresetReference", v.lineOf("type", kind, 0), "Preset %q adds block %q, which the section doesn't define", name, kind)
+				}
+				continue
+			}
+			var values map[string]json.RawMessage
+			json.Unmarshal(block["settings"], &values)
+			for _, id := range sortedKeys(values) {
+				if !ids[id] {
+					v.report("error", "PresetReference", line, "Preset %q sets %q on block %q, which has no such setting", name, id, kind)
+				}
+			}
+		}
+	}
+}
+
+// presetBlocks reads preset blocks, which are an array for section blocks and
+// an object keyed by block ID for theme blocks
+func presetBlocks(raw json.RawMessage) []schemaObject {
+	var list []schemaObject
+	if json.Unmarshal(raw, &list) == nil {
+		return list
+	}
+	var byID map[string]schemaObject
+	json.Unmarshal(raw, &byID)
+	for _, id := range sortedKeys(byID) {
+		list = append(list, byID[id])
+	}
+	return list
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// validateSectionSchema checks the {% schema %} block of one section file
+func validateSectionSchema(path, content string, blockFiles map[string]bool) []Diagnostic {
+	var diagnostics []Diagnostic
+	schemas := 0
+	for _, tag := range liquidTags(content) {
+		if tag.Name != "schema" {
+			continue
+		}
+		schemas++
+		if schemas > 1 {
+			diagnostics = append(diagnostics, Diagnostic{Severity: "error", Tool: "schema", Check: "SchemaJSON", File: path, Line: tag.Line,
+				Message: "A section can only have one schema"})
+			continue
+		}
+		v := &schemaValidator{file: path, body: tag.Body, line: tag.BodyLine, blockFiles: blockFiles}
+		v.validate()
+		diagnostics = append(diagnostics, v.diagnostics...)
+	}
+	return diagnostics
+}
+
+// themeBlockFiles lists the theme blocks in src/liquid/blocks by name
+func themeBlockFiles() map[string]bool {
+	names := make(map[string]bool)
+	files, _ := filepath.Glob(filepath.Join(projectRoot(), "src", "liquid", "blocks", "*.liquid"))
+	for _, file := range files {
+		names[strings.TrimSuffix(filepath.Base(file), ".liquid")] = true
+	}
+	return names
+}
+
+// isSectionFile is whether a project-relative path is a section in src
+func isSectionFile(path string) bool {
+	return strings.HasPrefix(path, "src/liquid/sections/") && strings.HasSuffix(path, ".liquid")
+}
+
+// validateSchemas checks the schema of every section in src/liquid/sections
+func validateSchemas() ([]Diagnostic, int) {
+	var diagnostics []Diagnostic
+	sections := 0
+	blockFiles := themeBlockFiles()
+	root := projectRoot()
+	filepath.Walk(filepath.Join(root, "src", "liquid", "sections"), func(path string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() || filepath.Ext(path) != ".liquid" {
+			return nil
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil
+		}
+		relative, _ := filepath.Rel(root, path)
+		sections++
+		diagnostics = append(diagnostics, validateSectionSchema(filepath.ToSlash(relative), string(data), blockFiles)...)
+		return nil
+	})
+	return diagnostics, sections
+}
+
+// ValidateSchemas checks every section schema and replaces the earlier schema diagnostics
+func (b *Backend) ValidateSchemas() int {
+	diagnostics, _ := validateSchemas()
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	kept := b.diagnostics[:0]
+	for _, d := range b.diagnostics {
+		if d.Tool != "schema" {
+			kept = append(kept, d)
+		}
+	}
+	b.diagnostics = kept
+	b.addDiagnostics(diagnostics)
+	return len(diagnostics)
+}
+
+// schemasValidatedMsg reports how many schema problems the validation found
+type schemasValidatedMsg struct {
+	Count int
+}
+
+// validateSchemasCmd validates the section schemas off the UI goroutine
+func validateSchemasCmd(backend *Backend) tea.Cmd {
+	return func() tea.Msg {
+		return schemasValidatedMsg{Count: backend.ValidateSchemas()}
+	}
+}
+
+func runValidateCommand(args []string) int {
+	flags := flag.NewFlagSet("validate", flag.ContinueOnError)
+	jsonOutput := flags.Bool("json", false, "print the findings as JSON")
+	if err := flags.Parse(args); err == flag.ErrHelp {
+		return exitOK
+	} else if err != nil {
+		return exitUsage
+	}
+
+	diagnostics, sections := validateSchemas()
+	errors, warnings := countBySeverity(diagnostics)
+	if *jsonOutput {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		encoder.Encode(diagnostics)
+	} else {
+		for _, d := range diagnostics {
+			fmt.Printf("%s %s:%d %s (%s)\n", severityIcon(d.Severity), d.File, d.Line, d.Message, d.Check)
+		}
+		fmt.Printf("🧩 %d section schemas: %d errors, %d warnings\n", sections, errors, warnings)
+	}
+	if errors > 0 {
+		return exitFailed
+	}
+	return exitOK
+}
@@ -0,0 +1,125 @@
+package main
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+// section wraps a schema body in a minimal section file
+func section(schema string) string {
+	return "<div>{{ section.settings.title }}</div>\n{% schema %}\n" + schema + "\n{% endschema %}\n"
+}
+
+func TestValidateSectionSchema(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    []string // Check and line of each diagnostic
+	}{
+		{
+			name: "valid schema",
+			content: section(`{
+  "name": "Hero",
+  "settings": [
+    {"type": "text", "id": "title", "label": "Title", "default": "Hi"},
+    {"type": "range", "id": "size", "label": "Size", "min": 1, "max": 5, "step": 1, "default": 3},
+    {"type": "select", "id": "align", "label": "Align", "options": [{"value": "l", "label": "L"}], "default": "l"}
+  ],
+  "blocks": [{"type": "slide", "name": "Slide", "settings": [{"type": "image_picker", "id": "image", "label": "Image"}]}],
+  "presets": [{"name": "Hero", "settings": {"title": "Hello"}, "blocks": [{"type": "slide"}]}]
+}`),
+		},
+		{
+			name:    "no schema at all",
+			content: "<div></div>\n",
+		},
+		{
+			name:    "invalid JSON reported at its line",
+			content: section("{\n  \"name\": \"Hero\",\n  \"settings\": [,]\n}"),
+			want:    []string{"SchemaJSON:5"},
+		},
+		{
+			name:    "two schemas",
+			content: section(`{"name": "A"}`) + "{% schema %}{\"name\": \"B\"}{% endschema %}",
+			want:    []string{"SchemaJSON:5"},
+		},
+		{
+			name: "missing fields",
+			content: section(`{
+  "settings": [
+    {"type": "text", "id": "title"},
+    {"type": "select", "id": "align", "label": "Align"}
+  ]
+}`),
+			want: []string{"MissingField:2", "MissingField:5", "MissingField:6"},
+		},
+		{
+			name:    "unknown setting type",
+			content: section(`{"name": "A", "settings": [{"type": "textt", "id": "title", "label": "T"}]}`),
+			want:    []string{"UnknownSettingType:3"},
+		},
+		{
+			name: "duplicate setting IDs and block types",
+			content: section(`{
+  "name": "A",
+  "settings": [
+    {"type": "text", "id": "title", "label": "T"},
+    {"type": "text", "id": "title", "label": "T2"}
+  ],
+  "blocks": [{"type": "slide", "name": "S"}, {"type": "slide", "name": "S2"}]
+}`),
+			want: []string{"DuplicateID:7", "DuplicateID:9"},
+		},
+		{
+			name: "defaults that don't fit their type",
+			content: section(`{
+  "name": "A",
+  "settings": [
+    {"type": "checkbox", "id": "on", "label": "On", "default": "yes"},
+    {"type": "range", "id": "size", "label": "Size", "min": 1, "max": 5, "step": 1, "default": 9},
+    {"type": "color", "id": "bg", "label": "Bg", "default": "blue"},
+    {"type": "product", "id": "product", "label": "Product", "default": "x"}
+  ]
+}`),
+			want: []string{"InvalidDefault:6", "InvalidDefault:7", "InvalidDefault:8", "InvalidDefault:9"},
+		},
+		{
+			name: "presets referencing unknown settings and blocks",
+			content: section(`{
+  "name": "A",
+  "settings": [{"type": "text", "id": "title", "label": "T"}],
+  "blocks": [{"type": "slide", "name": "S", "settings": [{"type": "text", "id": "caption", "label": "C"}]}],
+  "presets": [{
+    "name": "Default",
+    "settings": {"heading": "x"},
+    "blocks": [{"type": "slide", "settings": {"text": "y"}}, {"type": "card"}]
+  }]
+}`),
+			want: []string{"PresetReference:8", "PresetReference:8", "PresetReference:10"},
+		},
+		{
+			name: "theme blocks are allowed in presets when the section accepts them",
+			content: section(`{
+  "name": "A",
+  "blocks": [{"type": "@theme"}],
+  "presets": [{"name": "Default", "blocks": {"b1": {"type": "testimonial"}}}]
+}`),
+		},
+	}
+	blockFiles := map[string]bool{"testimonial": true}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var got []string
+			for _, d := range validateSectionSchema("src/liquid/sections/a.liquid", test.content, blockFiles) {
+				if d.Tool != "schema" || d.File != "src/liquid/sections/a.liquid" {
+					t.Errorf("diagnostic %+v has the wrong tool or file", d)
+				}
+				got = append(got, fmt.Sprintf("%s:%d", d.Check, d.Line))
+			}
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("got %v, want %v", got, test.want)
+			}
+		})
+	}
+}
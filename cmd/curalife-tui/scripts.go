@@ -0,0 +1,331 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// NpmScript is one entry of the package.json scripts block
+type NpmScript struct {
+	Name    string `json:"name"`
+	Command string `json:"command"`
+	Group   string `json:"group"`
+}
+
+// ScriptGroup is a run of scripts under one emoji header such as "🚀 --- PRIMARY COMMANDS ---"
+type ScriptGroup struct {
+	Name    string
+	Scripts []NpmScript
+}
+
+// ScriptRun records one execution of an npm script
+type ScriptRun struct {
+	Script     string    `json:"script"`
+	StartedAt  time.Time `json:"startedAt"`
+	FinishedAt time.Time `json:"finishedAt"`
+	IsRunning  bool      `json:"isRunning"`
+	ExitCode   int       `json:"exitCode"`
+	Output     []string  `json:"-"`
+}
+
+// Duration returns how long the run took, or has been running
+func (r ScriptRun) Duration() time.Duration {
+	if r.IsRunning || r.FinishedAt.IsZero() {
+		return time.Since(r.StartedAt).Round(time.Second)
+	}
+	return r.FinishedAt.Sub(r.StartedAt).Round(time.Millisecond)
+}
+
+// maxScriptHistory bounds the finished script runs kept for the Scripts screen
+const maxScriptHistory = 20
+
+// loadScripts reads package.json and groups scripts under their header keys, keeping file order
+func loadScripts() ([]ScriptGroup, error) {
+	file, err := os.Open(filepath.Join(projectRoot(), "package.json"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to open package.json: %v", err)
+	}
+	defer file.Close()
+
+	// Go maps lose key order, so walk the tokens of the "scripts" object by hand
+	decoder := json.NewDecoder(file)
+	if _, err := decoder.Token(); err != nil {
+		return nil, fmt.Errorf("failed to parse package.json: %v", err)
+	}
+	for decoder.More() {
+		key, err := decoder.Token()
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse package.json: %v", err)
+		}
+		if key != "scripts" {
+			var skip json.RawMessage
+			if err := decoder.Decode(&skip); err != nil {
+				return nil, fmt.Errorf("failed to parse package.json: %v", err)
+			}
+			continue
+		}
+		return decodeScriptGroups(decoder)
+	}
+	return nil, fmt.Errorf("package.json has no scripts")
+}
+
+func decodeScriptGroups(decoder *json.Decoder) ([]ScriptGroup, error) {
+	if _, err := decoder.Token(); err != nil {
+		return nil, fmt.Errorf("failed to parse scripts: %v", err)
+	}
+
+	groups := []ScriptGroup{{Name: "General"}}
+	for decoder.More() {
+		key, err := decoder.Token()
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse scripts: %v", err)
+		}
+		var command string
+		if err := decoder.Decode(&command); err != nil {
+			return nil, fmt.Errorf("failed to parse script %v: %v", key, err)
+		}
+
+		name := key.(string)
+		if strings.Contains(name, "---") {
+			groups = append(groups, ScriptGroup{Name: headerName(name)})
+			continue
+		}
+		group := &groups[len(groups)-1]
+		group.Scripts = append(group.Scripts, NpmScript{Name: name, Command: command, Group: group.Name})
+	}
+
+	// Drop the implicit first group when every script sits under a header
+	if len(groups[0].Scripts) == 0 {
+		groups = groups[1:]
+	}
+	return groups, nil
+}
+
+// headerName turns "🚀 --- PRIMARY COMMANDS ---" into "🚀 PRIMARY COMMANDS"
+func headerName(key string) string {
+	return strings.Join(strings.Fields(strings.ReplaceAll(key, "---", " ")), " ")
+}
+
+// fuzzyScore matches query as a subsequence of text. Consecutive matches and
+// matches at word starts score higher; ok is false when there is no match.
+func fuzzyScore(query, text string) (score int, ok bool) {
+	if query == "" {
+		return 0, true
+	}
+	query, text = strings.ToLower(query), strings.ToLower(text)
+	qi, streak := 0, 0
+	q := []rune(query)
+	prev := ' '
+	for _, r := range text {
+		if qi < len(q) && r == q[qi] {
+			streak++
+			score += streak * 2
+			if !unicode.IsLetter(prev) && !unicode.IsDigit(prev) {
+				score += 5
+			}
+			qi++
+		} else {
+			streak = 0
+		}
+		prev = r
+	}
+	if qi < len(q) {
+		return 0, false
+	}
+	return score - len(text)/10, true
+}
+
+// filterScripts returns scripts matching the query, best matches first; an empty query keeps file order
+func filterScripts(groups []ScriptGroup, query string) []NpmScript {
+	type scored struct {
+		script NpmScript
+		score  int
+	}
+	var matches []scored
+	for _, group := range groups {
+		for _, script := range group.Scripts {
+			nameScore, nameOK := fuzzyScore(query, script.Name)
+			commandScore, commandOK := fuzzyScore(query, script.Command)
+			if !nameOK && !commandOK {
+				continue
+			}
+			// Name matches rank above command matches
+			score := commandScore
+			if nameOK {
+				score = nameScore*2 + 100
+			}
+			matches = append(matches, scored{script, score})
+		}
+	}
+	if query != "" {
+		sort.SliceStable(matches, func(i, j int) bool { return matches[i].score > matches[j].score })
+	}
+
+	scripts := make([]NpmScript, len(matches))
+	for i, match := range matches {
+		scripts[i] = match.script
+	}
+	return scripts
+}
+
+// openScripts loads package.json and shows the Scripts screen
+func (m Model) openScripts() Model {
+	m.state = StateScripts
+	m.scriptCursor = 0
+	m.scriptQuery = ""
+	m.searchingScripts = false
+	groups, err := loadScripts()
+	m.scriptGroups = groups
+	if err != nil {
+		m.statusMessage = fmt.Sprintf("❌ %v", err)
+	}
+	return m
+}
+
+func (m Model) handleScriptsKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	scripts := filterScripts(m.scriptGroups, m.scriptQuery)
+
+	if m.searchingScripts {
+		switch msg.Type {
+		case tea.KeyEnter, tea.KeyEsc:
+			m.searchingScripts = false
+		case tea.KeyBackspace:
+			if len(m.scriptQuery) > 0 {
+				runes := []rune(m.scriptQuery)
+				m.scriptQuery = string(runes[:len(runes)-1])
+			}
+			m.scriptCursor = 0
+		case tea.KeyRunes, tea.KeySpace:
+			m.scriptQuery += string(msg.Runes)
+			m.scriptCursor = 0
+		case tea.KeyUp, tea.KeyDown:
+			m.searchingScripts = false
+			return m.handleScriptsKeys(msg)
+		}
+		return m, nil
+	}
+
+	switch msg.String() {
+	case "ctrl+c", "q":
+		m.backend.Cleanup()
+		return m, tea.Quit
+	case "esc":
+		if m.scriptQuery != "" {
+			m.scriptQuery = ""
+			m.scriptCursor = 0
+		} else {
+			m.state = StateMenu
+		}
+	case "/":
+		m.searchingScripts = true
+	case "up", "k":
+		if m.scriptCursor > 0 {
+			m.scriptCursor--
+		}
+	case "down", "j":
+		if m.scriptCursor < len(scripts)-1 {
+			m.scriptCursor++
+		}
+	case "enter":
+		if m.scriptCursor < len(scripts) {
+			name := scripts[m.scriptCursor].Name
+			if err := m.backend.StartScript(name); err != nil {
+				m.statusMessage = fmt.Sprintf("❌ %v", err)
+			} else {
+				m.statusMessage = fmt.Sprintf("▶ npm run %s", name)
+			}
+		}
+	case "x":
+		if err := m.backend.StopScript(); err != nil {
+			m.statusMessage = fmt.Sprintf("❌ %v", err)
+		}
+	case "r":
+		return m.openScripts(), nil
+	}
+	return m, nil
+}
+
+func (m Model) renderScripts() string {
+	s := "\n"
+	s += titleStyle.Render("📜 NPM SCRIPTS") + "\n\n"
+
+	search := m.scriptQuery
+	if m.searchingScripts {
+		search += "█"
+	}
+	if search != "" || m.searchingScripts {
+		s += infoStyle.Render(fmt.Sprintf("🔍 %s", search)) + "\n\n"
+	}
+
+	scripts := filterScripts(m.scriptGroups, m.scriptQuery)
+
+	// Keep the cursor visible in a window of scripts
+	const visible = 14
+	start := 0
+	if m.scriptCursor >= visible {
+		start = m.scriptCursor - visible + 1
+	}
+	group := ""
+	for i := start; i < len(scripts) && i < start+visible; i++ {
+		script := scripts[i]
+		if m.scriptQuery == "" && script.Group != group {
+			group = script.Group
+			s += statsStyle.Render(group) + "\n"
+		}
+		line := truncate(fmt.Sprintf("%-24s %s", script.Name, script.Command), 100)
+		if i == m.scriptCursor {
+			s += selectedStyle.Render("> "+line) + "\n"
+		} else {
+			s += normalStyle.Render("  "+line) + "\n"
+		}
+	}
+	if len(scripts) == 0 {
+		s += detailStyle.Render("No matching scripts") + "\n"
+	}
+
+	if run, ok := m.backend.GetScriptRun(); ok {
+		status := "🔄 Running"
+		if !run.IsRunning {
+			status = "✅ Exit 0"
+			if run.ExitCode != 0 {
+				status = fmt.Sprintf("❌ Exit %d", run.ExitCode)
+			}
+		}
+		s += "\n" + statsStyle.Render(fmt.Sprintf("📤 npm run %s — %s (%s)", run.Script, status, run.Duration())) + "\n"
+		output := run.Output
+		if len(output) > 10 {
+			output = output[len(output)-10:]
+		}
+		for _, line := range output {
+			s += detailStyle.Render("  "+truncate(line, 120)) + "\n"
+		}
+	}
+
+	if history := m.backend.GetScriptHistory(); len(history) > 0 {
+		s += "\n" + statsStyle.Render("🕘 History:") + "\n"
+		for i := len(history) - 1; i >= 0 && i >= len(history)-5; i-- {
+			run := history[i]
+			icon := "✅"
+			if run.ExitCode != 0 {
+				icon = "❌"
+			}
+			s += detailStyle.Render(fmt.Sprintf("  %s %s %-24s exit %d in %s",
+				icon, run.StartedAt.Format("15:04:05"), run.Script, run.ExitCode, run.Duration())) + "\n"
+		}
+	}
+
+	if m.statusMessage != "" {
+		s += "\n" + infoStyle.Render(m.statusMessage) + "\n"
+	}
+
+	s += "\n" + helpStyle.Render("/: search • ↑/↓: navigate • enter: run • x: stop • r: reload package.json • esc: back") + "\n"
+	return s
+}
@@ -0,0 +1,497 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// liquidKeywords are words in Liquid expressions that aren't variables
+var liquidKeywords = map[string]bool{
+	"and": true, "or": true, "contains": true, "in": true, "with": true, "as": true, "for": true,
+	"true": true, "false": true, "nil": true, "null": true, "empty": true, "blank": true,
+	"limit": true, "offset": true, "reversed": true, "cols": true, "by": true,
+}
+
+// liquidGlobals are the objects Shopify makes available everywhere, or on the
+// templates for their resource, so snippets can read them without a parameter
+var liquidGlobals = map[string]bool{
+	"all_products": true, "articles": true, "blogs": true, "canonical_url": true, "cart": true,
+	"collections": true, "content_for_header": true, "content_for_layout": true, "current_page": true,
+	"current_tags": true, "customer": true, "forloop": true, "handle": true, "images": true,
+	"linklists": true, "localization": true, "metaobjects": true, "page_description": true,
+	"page_image": true, "page_title": true, "pages": true, "powered_by_link": true, "predictive_search": true,
+	"request": true, "routes": true, "scripts": true, "settings": true, "shop": true, "tablerowloop": true,
+	"template": true, "theme": true, "additional_checkout_buttons": true, "recommendations": true,
+	// Resource objects of their own templates
+	"article": true, "blog": true, "collection": true, "gift_card": true, "order": true,
+	"page": true, "product": true, "search": true, "checkout": true,
+}
+
+// SnippetCall is one render or include of a snippet
+type SnippetCall struct {
+	File    string
+	Line    int
+	Include bool     // include shares the caller's variables, so nothing has to be passed
+	Params  []string // parameter names, including the with/for alias
+}
+
+// ParamIssue is a parameter problem found at one call site or in the snippet
+type ParamIssue struct {
+	Param string
+	File  string
+	Line  int
+}
+
+// SnippetContract is what a snippet reads and what its callers pass
+type SnippetContract struct {
+	Snippet ThemeFile
+	// Reads are the variables read before the snippet assigns them, globals included
+	Reads   []string
+	Assigns []string
+	Calls   []SnippetCall
+	// Unknown are parameters a call site passes but the snippet never reads
+	Unknown []ParamIssue
+	// Unsupplied are parameters the snippet reads that no call site passes
+	Unsupplied []ParamIssue
+}
+
+// Problems counts the issues of the contract
+func (c SnippetContract) Problems() int {
+	return len(c.Unknown) + len(c.Unsupplied)
+}
+
+// expressionVariables lists the root variables an expression reads: not
+// properties, filter names, named argument keys, strings or keywords
+func expressionVariables(expression string) []string {
+	var variables []string
+	// Blank out quoted strings so their words don't count
+	runes := []rune(expression)
+	var quote rune
+	for i, r := range runes {
+		switch {
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			}
+			runes[i] = ' '
+		case r == '\'' || r == '"':
+			quote = r
+			runes[i] = ' '
+		}
+	}
+	expression = string(runes)
+
+	isWord := func(b byte) bool {
+		return b == '_' || b == '-' || b == '?' || b >= '0' && b <= '9' || b >= 'a' && b <= 'z' || b >= 'A' && b <= 'Z'
+	}
+	for i := 0; i < len(expression); {
+		b := expression[i]
+		if !(b == '_' || b >= 'a' && b <= 'z' || b >= 'A' && b <= 'Z') {
+			i++
+			continue
+		}
+		start := i
+		for i < len(expression) && isWord(expression[i]) {
+			i++
+		}
+		word := strings.TrimRight(expression[start:i], "-?")
+		if start > 0 && isWord(expression[start-1]) {
+			continue
+		}
+		before := strings.TrimRight(expression[:start], " \t\n")
+		after := strings.TrimLeft(expression[i:], " \t\n")
+		switch {
+		case strings.HasSuffix(before, ".") && !strings.HasSuffix(before, ".."):
+			// a property
+		case strings.HasSuffix(before, "|"):
+			// a filter
+		case strings.HasPrefix(after, ":"):
+			// a named argument
+		case liquidKeywords[word]:
+		default:
+			variables = append(variables, word)
+		}
+	}
+	return variables
+}
+
+// snippetCall parses the markup of a render or include tag: the snippet
+// name, its parameters and the expressions passed to them
+func snippetCall(markup string) (string, []string, []string, bool) {
+	name, ok := quotedName(markup)
+	if !ok {
+		return "", nil, nil, false
+	}
+	// Calls split over several lines read the same as on one
+	rest := strings.Join(strings.Fields(markup[len(name)+2:]), " ")
+	var params, values []string
+
+	// with/for expression [as alias] binds the alias, or the snippet name
+	if keyword, remainder, found := strings.Cut(rest, " "); found && (keyword == "with" || keyword == "for") {
+		expression, alias := remainder, name
+		if before, after, ok := strings.Cut(remainder, " as "); ok {
+			expression = before
+			alias = strings.TrimSpace(strings.SplitN(after, ",", 2)[0])
+			remainder = ""
+			if _, more, ok := strings.Cut(after, ","); ok {
+				remainder = more
+			}
+		} else if before, after, ok := strings.Cut(remainder, ","); ok {
+			expression, remainder = before, after
+		} else {
+			remainder = ""
+		}
+		params = append(params, alias)
+		values = append(values, expression)
+		rest = remainder
+	}
+
+	for _, argument := range splitArguments(strings.TrimPrefix(strings.TrimSpace(rest), ",")) {
+		key, value, ok := strings.Cut(argument, ":")
+		if !ok {
+			continue
+		}
+		params = append(params, strings.TrimSpace(key))
+		values = append(values, value)
+	}
+	return name, params, values, true
+}
+
+// splitArguments splits "a: x, b: 'c, d'" on commas outside strings
+func splitArguments(text string) []string {
+	var arguments []string
+	var quote rune
+	start := 0
+	for i, r := range text {
+		switch {
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			}
+		case r == '\'' || r == '"':
+			quote = r
+		case r == ',':
+			arguments = append(arguments, text[start:i])
+			start = i + 1
+		}
+	}
+	if strings.TrimSpace(text[start:]) != "" {
+		arguments = append(arguments, text[start:])
+	}
+	return arguments
+}
+
+// liquidScope walks a file's tags in order and records the variables it reads
+// before assigning them, the ones it assigns, and the snippets it includes
+type liquidScope struct {
+	reads    []string
+	assigns  []string
+	includes []string
+	assigned map[string]bool
+	read     map[string]bool
+}
+
+func (s *liquidScope) readAll(expression string) {
+	for _, variable := range expressionVariables(expression) {
+		if !s.assigned[variable] && !s.read[variable] {
+			s.read[variable] = true
+			s.reads = append(s.reads, variable)
+		}
+	}
+}
+
+func (s *liquidScope) assign(variable string) {
+	variable = strings.TrimSpace(variable)
+	if variable != "" && !s.assigned[variable] {
+		s.assigned[variable] = true
+		s.assigns = append(s.assigns, variable)
+	}
+}
+
+// scanScope runs the tags of a Liquid file through a fresh scope
+func scanScope(content string) *liquidScope {
+	s := &liquidScope{assigned: make(map[string]bool), read: make(map[string]bool)}
+	for _, tag := range liquidTags(content) {
+		if strings.HasPrefix(tag.Name, "#") {
+			// an inline comment
+			continue
+		}
+		switch tag.Name {
+		case "assign":
+			name, expression, _ := strings.Cut(tag.Markup, "=")
+			s.readAll(expression)
+			s.assign(name)
+		case "capture", "increment", "decrement":
+			s.assign(strings.Fields(tag.Markup + " _")[0])
+		case "for", "tablerow":
+			name, expression, _ := strings.Cut(tag.Markup, " in ")
+			s.readAll(expression)
+			s.assign(name)
+		case "render", "include":
+			name, _, values, ok := snippetCall(tag.Markup)
+			if !ok {
+				s.readAll(tag.Markup)
+				continue
+			}
+			for _, value := range values {
+				s.readAll(value)
+			}
+			if tag.Name == "include" {
+				s.includes = append(s.includes, name)
+			}
+		case "section", "sections", "content_for", "comment", "raw", "schema", "javascript", "stylesheet":
+		default:
+			s.readAll(tag.Markup)
+		}
+	}
+	return s
+}
+
+// snippetContracts works out the contract of every snippet and checks it
+// against every render and include of it in the theme
+func snippetContracts(index ThemeIndex) []SnippetContract {
+	root := projectRoot()
+	contents := make(map[string]string)
+	for _, file := range index.Files {
+		if filepath.Ext(file.Path) != ".liquid" {
+			continue
+		}
+		if data, err := os.ReadFile(filepath.Join(root, file.Path)); err == nil {
+			contents[file.Path] = string(data)
+		}
+	}
+	return checkContracts(index.Files, contents)
+}
+
+// checkContracts does the work of snippetContracts on the content of each Liquid file, by path
+func checkContracts(files []ThemeFile, contents map[string]string) []SnippetContract {
+	scopes := make(map[string]*liquidScope)
+	calls := make(map[string][]SnippetCall)
+	for _, file := range files {
+		content, ok := contents[file.Path]
+		if !ok {
+			continue
+		}
+		if file.Kind == "snippet" {
+			scopes[file.Name] = scanScope(content)
+		}
+		for _, tag := range liquidTags(content) {
+			if tag.Name != "render" && tag.Name != "include" {
+				continue
+			}
+			if name, params, _, ok := snippetCall(tag.Markup); ok {
+				calls[name] = append(calls[name], SnippetCall{File: file.Path, Line: tag.Line, Include: tag.Name == "include", Params: params})
+			}
+		}
+	}
+
+	// An included snippet reads from its includer's scope, so its reads count too
+	var readsOf func(name string, seen map[string]bool) map[string]bool
+	readsOf = func(name string, seen map[string]bool) map[string]bool {
+		reads := make(map[string]bool)
+		scope, ok := scopes[name]
+		if !ok || seen[name] {
+			return reads
+		}
+		seen[name] = true
+		for _, variable := range scope.reads {
+			reads[variable] = true
+		}
+		for _, included := range scope.includes {
+			for variable := range readsOf(included, seen) {
+				if !scope.assigned[variable] {
+					reads[variable] = true
+				}
+			}
+		}
+		return reads
+	}
+
+	var contracts []SnippetContract
+	for _, file := range files {
+		if file.Kind != "snippet" || scopes[file.Name] == nil {
+			continue
+		}
+		scope := scopes[file.Name]
+		reads := readsOf(file.Name, make(map[string]bool))
+		contract := SnippetContract{Snippet: file, Reads: sortedKeys(reads), Assigns: scope.assigns, Calls: calls[file.Name]}
+
+		supplied := make(map[string]bool)
+		included := false
+		for _, call := range contract.Calls {
+			included = included || call.Include
+			for _, param := range call.Params {
+				supplied[param] = true
+				if !reads[param] {
+					contract.Unknown = append(contract.Unknown, ParamIssue{Param: param, File: call.File, Line: call.Line})
+				}
+			}
+		}
+		// Included snippets see their caller's variables, and unused ones have no callers to check
+		if !included && len(contract.Calls) > 0 {
+			for _, variable := range contract.Reads {
+				if !supplied[variable] && !liquidGlobals[variable] {
+					contract.Unsupplied = append(contract.Unsupplied, ParamIssue{Param: variable, File: file.Path, Line: firstUse(contents[file.Path], variable)})
+				}
+			}
+		}
+		contracts = append(contracts, contract)
+	}
+	sort.SliceStable(contracts, func(i, j int) bool {
+		return contracts[i].Problems() > contracts[j].Problems()
+	})
+	return contracts
+}
+
+// firstUse finds the line where a snippet first mentions a variable
+func firstUse(content, variable string) int {
+	for _, tag := range liquidTags(content) {
+		for _, read := range expressionVariables(tag.Markup) {
+			if read == variable {
+				return tag.Line
+			}
+		}
+	}
+	return 0
+}
+
+// visibleContracts are the contracts the screen lists: only those with problems unless all are shown
+func (m Model) visibleContracts() []SnippetContract {
+	if m.paramsShowAll {
+		return m.contracts
+	}
+	var contracts []SnippetContract
+	for _, contract := range m.contracts {
+		if contract.Problems() > 0 {
+			contracts = append(contracts, contract)
+		}
+	}
+	return contracts
+}
+
+func (m Model) openParams() Model {
+	m.state = StateParams
+	m.contracts = snippetContracts(buildThemeIndex())
+	m.paramsCursor = 0
+	m.statusMessage = ""
+	return m
+}
+
+func (m Model) handleParamsKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	contracts := m.visibleContracts()
+	switch msg.String() {
+	case "ctrl+c", "q":
+		m.backend.Cleanup()
+		return m, tea.Quit
+	case "esc":
+		m.state = StateMenu
+	case "up", "k":
+		if m.paramsCursor > 0 {
+			m.paramsCursor--
+		}
+	case "down", "j":
+		if m.paramsCursor < len(contracts)-1 {
+			m.paramsCursor++
+		}
+	case "a":
+		m.paramsShowAll = !m.paramsShowAll
+		m.paramsCursor = 0
+	case "e", "enter":
+		if m.paramsCursor < len(contracts) {
+			return m, openInEditor(FileRef{Path: filepath.Join(projectRoot(), contracts[m.paramsCursor].Snippet.Path)})
+		}
+	case "r":
+		return m.openParams(), nil
+	}
+	return m, nil
+}
+
+func (m Model) renderParams() string {
+	s := "\n"
+	s += titleStyle.Render("📐 SNIPPET PARAMETERS") + "\n\n"
+
+	contracts := m.visibleContracts()
+	problems := 0
+	for _, contract := range m.contracts {
+		if contract.Problems() > 0 {
+			problems++
+		}
+	}
+	s += statusStyle.Render(fmt.Sprintf("%d snippets • %d with parameter problems", len(m.contracts), problems)) + "\n\n"
+	if len(contracts) == 0 {
+		s += statusStyle.Render("✅ Every render call matches its snippet") + "\n"
+	}
+
+	// Keep the cursor visible in a window of snippets
+	const visible = 10
+	start := 0
+	if m.paramsCursor >= visible {
+		start = m.paramsCursor - visible + 1
+	}
+	for i := start; i < len(contracts) && i < start+visible; i++ {
+		contract := contracts[i]
+		line := fmt.Sprintf("%-45s %3d calls  %2d unknown  %2d never passed", truncate(contract.Snippet.Name, 45),
+			len(contract.Calls), len(contract.Unknown), len(contract.Unsupplied))
+		if i == m.paramsCursor {
+			s += selectedStyle.Render("> "+line) + "\n"
+		} else {
+			s += normalStyle.Render("  "+line) + "\n"
+		}
+	}
+
+	if m.paramsCursor < len(contracts) {
+		contract := contracts[m.paramsCursor]
+		var lines []string
+		lines = append(lines, statsStyle.Render("📄 "+contract.Snippet.Path))
+		lines = append(lines, detailStyle.Render("  Reads:   "+joinOrNone(contract.Reads)))
+		lines = append(lines, detailStyle.Render("  Assigns: "+joinOrNone(contract.Assigns)))
+		if len(contract.Unknown) > 0 {
+			lines = append(lines, "", errorStyle.Render("❓ Passed but never read:"))
+			for _, issue := range contract.Unknown {
+				lines = append(lines, errorStyle.Render(fmt.Sprintf("  %-24s %s:%d", issue.Param, issue.File, issue.Line)))
+			}
+		}
+		if len(contract.Unsupplied) > 0 {
+			lines = append(lines, "", errorStyle.Render("🕳️  Read but never passed (renders empty):"))
+			for _, issue := range contract.Unsupplied {
+				lines = append(lines, errorStyle.Render(fmt.Sprintf("  %-24s first used at line %d", issue.Param, issue.Line)))
+			}
+		}
+		lines = append(lines, "", statsStyle.Render(fmt.Sprintf("📞 Call sites (%d):", len(contract.Calls))))
+		for _, call := range contract.Calls {
+			tag := "render"
+			if call.Include {
+				tag = "include"
+			}
+			lines = append(lines, detailStyle.Render(fmt.Sprintf("  %s:%d %s %s", call.File, call.Line, tag, joinOrNone(call.Params))))
+		}
+		room := max(m.visibleLines()-visible-6, 5)
+		if len(lines) > room {
+			lines = append(lines[:room-1], detailStyle.Render(fmt.Sprintf("  … %d more", len(lines)-room+1)))
+		}
+		s += "\n" + strings.Join(lines, "\n") + "\n"
+	}
+
+	if m.statusMessage != "" {
+		s += "\n" + infoStyle.Render(m.statusMessage) + "\n"
+	}
+
+	toggle := "a: show all snippets"
+	if m.paramsShowAll {
+		toggle = "a: only snippets with problems"
+	}
+	s += "\n" + helpStyle.Render("↑/↓: select • "+toggle+" • e: open snippet • r: rescan • esc: return to menu") + "\n"
+	return s
+}
+
+func joinOrNone(values []string) string {
+	if len(values) == 0 {
+		return "none"
+	}
+	return strings.Join(values, ", ")
+}
@@ -0,0 +1,248 @@
+package main
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestExpressionVariables(t *testing.T) {
+	tests := []struct {
+		expression string
+		want       []string
+	}{
+		{"product.title | escape", []string{"product"}},
+		{"product.price | money_with_currency | default: fallback_price", []string{"product", "fallback_price"}},
+		{"product.images[index].src", []string{"product", "index"}},
+		{"product['title'] | t: name: customer.first_name", []string{"product", "customer"}},
+		{"(1..max_items)", []string{"max_items"}},
+		{"show_vendor and product.vendor != blank", []string{"show_vendor", "product"}},
+		{"block.settings.heading contains 'sale price'", []string{"block"}},
+		{"image_url: width: 300", nil},
+		{"items limit: count offset: 2", []string{"items", "count"}},
+		{"is_active?", []string{"is_active"}},
+		{"'a b' | append: \"c d\"", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.expression, func(t *testing.T) {
+			if got := expressionVariables(tt.expression); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("expressionVariables(%q) = %q, want %q", tt.expression, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSnippetCall(t *testing.T) {
+	tests := []struct {
+		name       string
+		markup     string
+		wantName   string
+		wantParams []string
+		wantValues []string
+	}{
+		{
+			name:       "named arguments",
+			markup:     "'price', product: product, show_compare: true",
+			wantName:   "price",
+			wantParams: []string{"product", "show_compare"},
+			wantValues: []string{"product", "true"},
+		},
+		{
+			name:       "commas and quotes inside strings",
+			markup:     `'icon', name: 'cart', class: "icon, icon-cart"`,
+			wantName:   "icon",
+			wantParams: []string{"name", "class"},
+			wantValues: []string{"'cart'", `"icon, icon-cart"`},
+		},
+		{
+			name:       "filters in values",
+			markup:     `"card", heading: section.settings.title | upcase`,
+			wantName:   "card",
+			wantParams: []string{"heading"},
+			wantValues: []string{"section.settings.title | upcase"},
+		},
+		{
+			name:       "with binds the snippet name",
+			markup:     "'card' with product",
+			wantName:   "card",
+			wantParams: []string{"card"},
+			wantValues: []string{"product"},
+		},
+		{
+			name:       "with and more arguments",
+			markup:     "'card' with product, class: 'x, y'",
+			wantName:   "card",
+			wantParams: []string{"card", "class"},
+			wantValues: []string{"product", "'x, y'"},
+		},
+		{
+			name:       "with as alias",
+			markup:     "'card' with product as item, size: 'large'",
+			wantName:   "card",
+			wantParams: []string{"item", "size"},
+			wantValues: []string{"product", "'large'"},
+		},
+		{
+			name:       "for as alias",
+			markup:     "'card' for collection.products as item",
+			wantName:   "card",
+			wantParams: []string{"item"},
+			wantValues: []string{"collection.products"},
+		},
+		{
+			name:       "for binds the snippet name",
+			markup:     "'card' for products",
+			wantName:   "card",
+			wantParams: []string{"card"},
+			wantValues: []string{"products"},
+		},
+		{
+			name:       "split over lines",
+			markup:     "'card'\n\t\twith product\n\t\tas item,\n\t\tsize: 'large'",
+			wantName:   "card",
+			wantParams: []string{"item", "size"},
+			wantValues: []string{"product", "'large'"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			name, params, values, ok := snippetCall(tt.markup)
+			if !ok {
+				t.Fatalf("snippetCall(%q) found no snippet name", tt.markup)
+			}
+			for i := range values {
+				values[i] = strings.TrimSpace(values[i])
+			}
+			if name != tt.wantName || !reflect.DeepEqual(params, tt.wantParams) || !reflect.DeepEqual(values, tt.wantValues) {
+				t.Errorf("snippetCall(%q) = %q %q %q, want %q %q %q", tt.markup, name, params, values, tt.wantName, tt.wantParams, tt.wantValues)
+			}
+		})
+	}
+
+	if _, _, _, ok := snippetCall("block.type, block: block"); ok {
+		t.Error("a variable snippet name should not parse as a call")
+	}
+}
+
+func TestScanScope(t *testing.T) {
+	tests := []struct {
+		name         string
+		content      string
+		wantReads    []string
+		wantAssigns  []string
+		wantIncludes []string
+	}{
+		{
+			name:        "assign reads its expression first",
+			content:     `{% assign title = heading | default: product.title %}<h2>{{ title }}</h2>`,
+			wantReads:   []string{"heading", "product"},
+			wantAssigns: []string{"title"},
+		},
+		{
+			name:        "read before it is assigned",
+			content:     `{{ size }}{% assign size = 'large' %}{{ size }}`,
+			wantReads:   []string{"size"},
+			wantAssigns: []string{"size"},
+		},
+		{
+			name:        "capture",
+			content:     `{% capture label %}{{ name | escape }}{% endcapture %}{{ label }}`,
+			wantReads:   []string{"name"},
+			wantAssigns: []string{"label"},
+		},
+		{
+			name:        "for loop variable",
+			content:     `{% for image in product.images limit: max_images %}{{ image.src | image_url }}{% endfor %}`,
+			wantReads:   []string{"product", "max_images"},
+			wantAssigns: []string{"image"},
+		},
+		{
+			name:         "render values and includes",
+			content:      `{% render 'price', product: item, show_compare: compare %}{% include 'badge' %}`,
+			wantReads:    []string{"item", "compare"},
+			wantIncludes: []string{"badge"},
+		},
+		{
+			name:      "comments and schema are skipped",
+			content:   `{% comment %}{{ secret }}{% endcomment %}{% # {{ other }} %}{% schema %}{"name": "{{ x }}"}{% endschema %}{{ shown }}`,
+			wantReads: []string{"shown"},
+		},
+		{
+			name: "liquid tag",
+			content: `{% liquid
+  assign price = product.price | times: quantity
+  echo price | money
+%}`,
+			wantReads:   []string{"product", "quantity"},
+			wantAssigns: []string{"price"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scope := scanScope(tt.content)
+			if !reflect.DeepEqual(scope.reads, tt.wantReads) {
+				t.Errorf("reads = %q, want %q", scope.reads, tt.wantReads)
+			}
+			if !reflect.DeepEqual(scope.assigns, tt.wantAssigns) {
+				t.Errorf("assigns = %q, want %q", scope.assigns, tt.wantAssigns)
+			}
+			if !reflect.DeepEqual(scope.includes, tt.wantIncludes) {
+				t.Errorf("includes = %q, want %q", scope.includes, tt.wantIncludes)
+			}
+		})
+	}
+}
+
+func TestCheckContracts(t *testing.T) {
+	files := []ThemeFile{
+		{Kind: "section", Name: "main-product", Path: "src/liquid/sections/main-product.liquid"},
+		{Kind: "snippet", Name: "card", Path: "src/liquid/snippets/card.liquid"},
+		{Kind: "snippet", Name: "card-title", Path: "src/liquid/snippets/card-title.liquid"},
+		{Kind: "snippet", Name: "loop-a", Path: "src/liquid/snippets/loop-a.liquid"},
+		{Kind: "snippet", Name: "loop-b", Path: "src/liquid/snippets/loop-b.liquid"},
+	}
+	contents := map[string]string{
+		"src/liquid/sections/main-product.liquid": `{% render 'card', product: product, badge_text: 'New', colour: 'red' %}
+{% render 'loop-a' with product as item %}`,
+		// card sets title for the snippet it includes, but not subtitle
+		"src/liquid/snippets/card.liquid": `{% assign title = product.title %}
+{% include 'card-title' %}
+<span>{{ badge_text }}</span>`,
+		"src/liquid/snippets/card-title.liquid": `<h3>{{ title }}</h3>
+<p>{{ subtitle }}</p>`,
+		"src/liquid/snippets/loop-a.liquid": `{{ item.title }}{% include 'loop-b' %}`,
+		"src/liquid/snippets/loop-b.liquid": `{{ item.vendor }}{% include 'loop-a' %}`,
+	}
+
+	contracts := make(map[string]SnippetContract)
+	for _, contract := range checkContracts(files, contents) {
+		contracts[contract.Snippet.Name] = contract
+	}
+
+	card := contracts["card"]
+	if want := []string{"badge_text", "product", "subtitle"}; !reflect.DeepEqual(card.Reads, want) {
+		t.Errorf("card reads %q, want %q: its own reads plus the included snippet's, less what it assigns", card.Reads, want)
+	}
+	if want := []ParamIssue{{Param: "colour", File: "src/liquid/sections/main-product.liquid", Line: 1}}; !reflect.DeepEqual(card.Unknown, want) {
+		t.Errorf("card unknown params %+v, want %+v", card.Unknown, want)
+	}
+	if len(card.Unsupplied) != 1 || card.Unsupplied[0].Param != "subtitle" {
+		t.Errorf("card unsupplied params %+v, want only subtitle", card.Unsupplied)
+	}
+
+	title := contracts["card-title"]
+	if want := []string{"subtitle", "title"}; !reflect.DeepEqual(title.Reads, want) {
+		t.Errorf("card-title reads %q, want %q", title.Reads, want)
+	}
+	if title.Problems() != 0 {
+		t.Errorf("card-title is only included, so it should have no problems: %+v %+v", title.Unknown, title.Unsupplied)
+	}
+
+	loop := contracts["loop-a"]
+	if want := []string{"item"}; !reflect.DeepEqual(loop.Reads, want) {
+		t.Errorf("loop-a reads %q, want %q", loop.Reads, want)
+	}
+	if loop.Problems() != 0 {
+		t.Errorf("loop-a gets item through with … as, so it should have no problems: %+v %+v", loop.Unknown, loop.Unsupplied)
+	}
+}
@@ -0,0 +1,461 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// buildDirName is the theme directory the build writes and Theme Check reads
+const buildDirName = "Curalife-Theme-Build"
+
+// sourceDirMappings mirrors dirMappings in shared-utils.js: the first source
+// directory prefix that matches decides where a file lands in the build
+var sourceDirMappings = []struct{ Source, Build string }{
+	{"liquid/layout", "layout"},
+	{"liquid/sections", "sections"},
+	{"liquid/snippets", "snippets"},
+	{"liquid/blocks", "blocks"},
+	{"liquid/templates", "templates"},
+	{"config", "config"},
+	{"locales", "locales"},
+	{"assets", "assets"},
+	{"images", "assets"},
+	{"fonts", "assets"},
+	{"js", "assets"},
+	{"scripts", "assets"},
+}
+
+// buildDestination returns the build directory a src-relative file is copied to, like getDestination
+func buildDestination(relative string) string {
+	relative = filepath.ToSlash(relative)
+	for _, mapping := range sourceDirMappings {
+		if strings.HasPrefix(relative, mapping.Source) {
+			return mapping.Build
+		}
+	}
+	if !strings.HasSuffix(relative, ".liquid") {
+		return "assets"
+	}
+	for _, dir := range []string{"sections", "snippets", "blocks", "layout"} {
+		if strings.Contains(relative, dir) {
+			return dir
+		}
+	}
+	return "snippets"
+}
+
+// sourceIndex maps built files ("sections/hero.liquid") to the src file they
+// were copied from, relative to the project root. The build flattens nested
+// source directories, so the index is keyed by destination and base name.
+func sourceIndex() map[string]string {
+	index := make(map[string]string)
+	root := projectRoot()
+	srcDir := filepath.Join(root, "src")
+	filepath.Walk(srcDir, func(path string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() {
+			return nil
+		}
+		relative, err := filepath.Rel(srcDir, path)
+		if err != nil {
+			return nil
+		}
+		built := buildDestination(relative) + "/" + info.Name()
+		if _, ok := index[built]; !ok {
+			index[built] = filepath.ToSlash(filepath.Join("src", relative))
+		}
+		return nil
+	})
+	return index
+}
+
+// builtPath normalizes a path reported by Theme Check to one relative to the build directory
+func builtPath(path string) string {
+	if filepath.IsAbs(path) {
+		if buildDir, err := filepath.Abs(filepath.Join(projectRoot(), buildDirName)); err == nil {
+			if relative, err := filepath.Rel(buildDir, path); err == nil && !strings.HasPrefix(relative, "..") {
+				path = relative
+			}
+		}
+	}
+	return strings.TrimPrefix(filepath.ToSlash(path), buildDirName+"/")
+}
+
+// themeCheckOffense is one offense in Theme Check's JSON output
+type themeCheckOffense struct {
+	Check       string `json:"check"`
+	Severity    int    `json:"severity"`
+	StartRow    int    `json:"start_row"`
+	StartColumn int    `json:"start_column"`
+	Message     string `json:"message"`
+}
+
+// themeCheckFile is the JSON output for one checked file
+type themeCheckFile struct {
+	Path     string              `json:"path"`
+	Offenses []themeCheckOffense `json:"offenses"`
+}
+
+// themeCheckSeverity maps Theme Check's error/suggestion/style levels to diagnostic severities
+func themeCheckSeverity(severity int) string {
+	switch severity {
+	case 0:
+		return "error"
+	case 1:
+		return "warning"
+	}
+	return "info"
+}
+
+// CheckOffense is a Theme Check offense located in its src file, plus the built copy it was found in
+type CheckOffense struct {
+	Diagnostic
+	Built string `json:"built"`
+}
+
+// ThemeCheckRun is the outcome of the latest Theme Check job
+type ThemeCheckRun struct {
+	Offenses   []CheckOffense
+	Files      int
+	FinishedAt time.Time
+	Err        error
+}
+
+// parseThemeCheck decodes the JSON report, skipping anything the CLI prints before it
+func parseThemeCheck(output string, sources map[string]string) ([]CheckOffense, int, error) {
+	// The report starts on a line of its own; log lines such as "[warn] ..." also start with "["
+	var files []themeCheckFile
+	err := fmt.Errorf("no JSON report in Theme Check output")
+	for offset := 0; offset < len(output); {
+		line, _, _ := strings.Cut(output[offset:], "\n")
+		if trimmed := strings.TrimLeft(line, " \t"); strings.HasPrefix(trimmed, "[") {
+			start := offset + len(line) - len(trimmed)
+			files = nil
+			decodeErr := json.NewDecoder(strings.NewReader(output[start:])).Decode(&files)
+			if decodeErr == nil {
+				err = nil
+				break
+			}
+			err = fmt.Errorf("failed to parse Theme Check output: %v", decodeErr)
+		}
+		offset += len(line) + 1
+	}
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var offenses []CheckOffense
+	for _, file := range files {
+		built := builtPath(file.Path)
+		source, ok := sources[built]
+		if !ok {
+			// Files such as templates/*.json only exist in the build
+			source = buildDirName + "/" + built
+		}
+		for _, offense := range file.Offenses {
+			offenses = append(offenses, CheckOffense{
+				Diagnostic: Diagnostic{
+					Severity: themeCheckSeverity(offense.Severity),
+					Tool:     "theme-check",
+					Check:    offense.Check,
+					File:     source,
+					Line:     offense.StartRow + 1,
+					Column:   offense.StartColumn + 1,
+					Message:  offense.Message,
+				},
+				Built: built,
+			})
+		}
+	}
+	return offenses, len(files), nil
+}
+
+// themeCheckCommand runs the Shopify CLI checker from the project root, where
+// .theme-check.yml points it at the build directory
+func themeCheckCommand() *exec.Cmd {
+	args := []string{"theme", "check", "--path", ".", "--output", "json"}
+	var cmd *exec.Cmd
+	if runtime.GOOS == "windows" {
+		cmd = exec.Command("cmd", append([]string{"/c", "shopify"}, args...)...)
+	} else {
+		cmd = exec.Command("shopify", args...)
+	}
+	cmd.Dir = projectRoot()
+	cmd.Env = append(os.Environ(), "FORCE_COLOR=0", "NO_COLOR=1")
+	return cmd
+}
+
+// StartThemeCheck runs Theme Check against the build directory as a job. Its
+// offenses replace earlier Theme Check diagnostics when it finishes.
+func (b *Backend) StartThemeCheck() error {
+	if _, err := os.Stat(filepath.Join(projectRoot(), buildDirName)); err != nil {
+		return fmt.Errorf("%s not found — run a build first", buildDirName)
+	}
+
+	var output strings.Builder
+	var outputMutex sync.Mutex
+	err := b.jobs.Start("theme-check", "check", "🔍 Theme Check", themeCheckCommand(), JobHandlers{
+		OnStdout: func(line string) {
+			outputMutex.Lock()
+			defer outputMutex.Unlock()
+			output.WriteString(line + "\n")
+		},
+		OnExit: func(job Job) {
+			outputMutex.Lock()
+			text := output.String()
+			outputMutex.Unlock()
+			b.finishThemeCheck(job, text)
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to start Theme Check: %v", err)
+	}
+	return nil
+}
+
+// finishThemeCheck stores the parsed report. The checker exits non-zero when
+// it finds errors, so the exit code alone doesn't mean the run failed.
+func (b *Backend) finishThemeCheck(job Job, output string) {
+	run := ThemeCheckRun{FinishedAt: job.FinishedAt}
+	if job.State == JobStopped {
+		run.Err = fmt.Errorf("Theme Check was stopped")
+	} else {
+		run.Offenses, run.Files, run.Err = parseThemeCheck(output, sourceIndex())
+	}
+
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	b.themeCheck = run
+	if run.Err != nil {
+		b.jobs.Log(job.ID, run.Err.Error())
+		return
+	}
+
+	kept := b.diagnostics[:0]
+	for _, d := range b.diagnostics {
+		if d.Tool != "theme-check" {
+			kept = append(kept, d)
+		}
+	}
+	b.diagnostics = kept
+	diagnostics := make([]Diagnostic, len(run.Offenses))
+	for i, offense := range run.Offenses {
+		diagnostics[i] = offense.Diagnostic
+	}
+	b.addDiagnostics(diagnostics)
+	b.jobs.Log(job.ID, fmt.Sprintf("%d offenses in %d files", len(run.Offenses), run.Files))
+}
+
+// GetThemeCheck returns the latest Theme Check run and whether one is in progress
+func (b *Backend) GetThemeCheck() (ThemeCheckRun, bool) {
+	b.mutex.RLock()
+	defer b.mutex.RUnlock()
+	return b.themeCheck, b.jobs.IsRunning("theme-check")
+}
+
+// themeCheckGroupings are the ways the offenses browser groups offenses, cycled with g
+var themeCheckGroupings = []string{"check", "severity", "file"}
+
+// offenseGroup is a heading in the offenses browser and the offenses under it
+type offenseGroup struct {
+	Title    string
+	Offenses []CheckOffense
+}
+
+// groupOffenses groups offenses by check, severity or source file. Severity
+// groups run from errors to info; the others are in name order.
+func groupOffenses(offenses []CheckOffense, grouping string) []offenseGroup {
+	key := func(offense CheckOffense) string {
+		switch grouping {
+		case "severity":
+			return offense.Severity
+		case "file":
+			return offense.File
+		}
+		return offense.Check
+	}
+	severityOrder := map[string]int{"error": 0, "warning": 1, "info": 2}
+
+	index := make(map[string]int)
+	var groups []offenseGroup
+	for _, offense := range offenses {
+		title := key(offense)
+		i, ok := index[title]
+		if !ok {
+			i = len(groups)
+			index[title] = i
+			groups = append(groups, offenseGroup{Title: title})
+		}
+		groups[i].Offenses = append(groups[i].Offenses, offense)
+	}
+
+	sort.SliceStable(groups, func(i, j int) bool {
+		if grouping == "severity" {
+			return severityOrder[groups[i].Title] < severityOrder[groups[j].Title]
+		}
+		return groups[i].Title < groups[j].Title
+	})
+	for _, group := range groups {
+		sort.SliceStable(group.Offenses, func(i, j int) bool {
+			a, b := group.Offenses[i], group.Offenses[j]
+			if a.File != b.File {
+				return a.File < b.File
+			}
+			return a.Line < b.Line
+		})
+	}
+	return groups
+}
+
+// flattenOffenses lists offenses in display order so the cursor can index them
+func flattenOffenses(groups []offenseGroup) []CheckOffense {
+	var flat []CheckOffense
+	for _, group := range groups {
+		flat = append(flat, group.Offenses...)
+	}
+	return flat
+}
+
+func severityIcon(severity string) string {
+	switch severity {
+	case "error":
+		return "❌"
+	case "warning":
+		return "⚠️ "
+	}
+	return "ℹ️ "
+}
+
+// openThemeCheck shows the offenses browser, starting a check if none has run yet
+func (m Model) openThemeCheck() Model {
+	m.state = StateThemeCheck
+	m.checkCursor = 0
+	m.statusMessage = ""
+	if run, running := m.backend.GetThemeCheck(); !running && run.FinishedAt.IsZero() {
+		if err := m.backend.StartThemeCheck(); err != nil {
+			m.statusMessage = fmt.Sprintf("❌ %v", err)
+		}
+	}
+	return m
+}
+
+func (m Model) handleThemeCheckKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	run, _ := m.backend.GetThemeCheck()
+	offenses := flattenOffenses(groupOffenses(run.Offenses, themeCheckGroupings[m.checkGrouping]))
+
+	switch msg.String() {
+	case "ctrl+c", "q":
+		m.backend.Cleanup()
+		return m, tea.Quit
+	case "esc":
+		m.state = StateMenu
+	case "up", "k":
+		if m.checkCursor > 0 {
+			m.checkCursor--
+		}
+	case "down", "j":
+		if m.checkCursor < len(offenses)-1 {
+			m.checkCursor++
+		}
+	case "g":
+		m.checkGrouping = (m.checkGrouping + 1) % len(themeCheckGroupings)
+		m.checkCursor = 0
+	case "e", "enter":
+		if m.checkCursor < len(offenses) {
+			return m, openInEditor(offenses[m.checkCursor].Ref())
+		}
+	case "r":
+		m.statusMessage = ""
+		m.checkCursor = 0
+		if err := m.backend.StartThemeCheck(); err != nil {
+			m.statusMessage = fmt.Sprintf("❌ %v", err)
+		}
+	case "x":
+		if err := m.backend.StopJob("theme-check"); err != nil {
+			m.statusMessage = fmt.Sprintf("❌ %v", err)
+		}
+	}
+	return m, nil
+}
+
+func (m Model) renderThemeCheck() string {
+	s := "\n"
+	s += titleStyle.Render("🔍 THEME CHECK") + "\n\n"
+
+	run, running := m.backend.GetThemeCheck()
+	grouping := themeCheckGroupings[m.checkGrouping]
+	groups := groupOffenses(run.Offenses, grouping)
+
+	switch {
+	case running:
+		s += statusStyle.Render("🔄 Checking "+buildDirName+"...") + "\n"
+	case run.Err != nil:
+		s += errorStyle.Render(fmt.Sprintf("❌ %v", run.Err)) + "\n"
+	case !run.FinishedAt.IsZero():
+		errors := 0
+		for _, offense := range run.Offenses {
+			if offense.Severity == "error" {
+				errors++
+			}
+		}
+		s += statusStyle.Render(fmt.Sprintf("%d offenses (%d errors) in %d files • checked %s",
+			len(run.Offenses), errors, run.Files, run.FinishedAt.Format("15:04:05"))) + "\n"
+	}
+	s += detailStyle.Render("Grouped by "+grouping) + "\n\n"
+
+	if !running && run.Err == nil && !run.FinishedAt.IsZero() && len(run.Offenses) == 0 {
+		s += statusStyle.Render("✅ No offenses") + "\n"
+	}
+
+	// Keep the selected offense in view
+	var lines []string
+	selectedLine := 0
+	var selected *CheckOffense
+	index := 0
+	for _, group := range groups {
+		lines = append(lines, statsStyle.Render(fmt.Sprintf("%s (%d)", group.Title, len(group.Offenses))))
+		for _, offense := range group.Offenses {
+			label := offense.Check
+			if grouping == "check" {
+				label = offense.File
+			}
+			line := fmt.Sprintf("%s %s:%d %s", severityIcon(offense.Severity), label, offense.Line, offense.Message)
+			line = truncate(line, max(m.width-4, 60))
+			if index == m.checkCursor {
+				lines = append(lines, selectedStyle.Render("> "+line))
+				selectedLine = len(lines) - 1
+				current := offense
+				selected = &current
+			} else {
+				lines = append(lines, normalStyle.Render("  "+line))
+			}
+			index++
+		}
+	}
+	if len(lines) > 0 {
+		visible := max(m.visibleLines()-6, 5)
+		scroll := min(max(selectedLine-visible/2, 0), max(len(lines)-visible, 0))
+		s += m.renderScrolled(lines, scroll)
+	}
+
+	if selected != nil {
+		s += "\n" + detailStyle.Render(fmt.Sprintf("%s • %s:%d:%d (built as %s)",
+			selected.Check, selected.File, selected.Line, selected.Column, selected.Built)) + "\n"
+	}
+
+	if m.statusMessage != "" {
+		s += "\n" + infoStyle.Render(m.statusMessage) + "\n"
+	}
+
+	s += "\n" + helpStyle.Render("↑/↓: select • e: open source in editor • g: group by check/severity/file • r: re-run • x: stop • esc: return to menu") + "\n"
+	return s
+}
@@ -0,0 +1,33 @@
+package main
+
+import "testing"
+
+func TestParseThemeCheck(t *testing.T) {
+	report := `[{"path":"sections/hero.liquid","offenses":[{"check":"UnknownFilter","severity":0,"start_row":3,"start_column":4,"message":"Undefined filter"}]}]`
+	tests := []struct {
+		name   string
+		output string
+	}{
+		{name: "report only", output: report},
+		{name: "bracket in a log line", output: "Checking theme [Curalife-Theme-Build]...\n" + report + "\n"},
+		{name: "log line starting with a bracket", output: "[warn] deprecated config key\n  " + report + "\n"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			offenses, files, err := parseThemeCheck(test.output, map[string]string{"sections/hero.liquid": "src/liquid/sections/hero.liquid"})
+			if err != nil {
+				t.Fatalf("parseThemeCheck: %v", err)
+			}
+			if files != 1 || len(offenses) != 1 {
+				t.Fatalf("got %d files, %d offenses, want 1 and 1", files, len(offenses))
+			}
+			if d := offenses[0].Diagnostic; d.File != "src/liquid/sections/hero.liquid" || d.Line != 4 || d.Column != 5 || d.Severity != "error" {
+				t.Errorf("got %s:%d:%d %s", d.File, d.Line, d.Column, d.Severity)
+			}
+		})
+	}
+
+	if _, _, err := parseThemeCheck("Theme Check crashed [exit 1]\n", nil); err == nil {
+		t.Error("expected an error without a report")
+	}
+}
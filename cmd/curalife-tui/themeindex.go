@@ -0,0 +1,449 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// ThemeFile is a Liquid file or JSON template the theme refers to by name
+type ThemeFile struct {
+	Kind string // layout, section, snippet, block, template or group
+	Name string // the name tags use: the base name without extension
+	Path string // relative to the project root
+}
+
+// ThemeRef is a reference from one theme file to another file or an asset
+type ThemeRef struct {
+	Kind string // render, include, section, sections, block or asset
+	Name string
+	Line int
+}
+
+// Target is the kind of file a reference resolves to
+func (r ThemeRef) Target() string {
+	switch r.Kind {
+	case "render", "include":
+		return "snippet"
+	case "sections":
+		return "group"
+	}
+	return r.Kind
+}
+
+// ThemeIndex is a static index of the Liquid in src plus the JSON templates
+// and section groups, which only exist in the build directory
+type ThemeIndex struct {
+	Files []ThemeFile // sorted by path
+	// Refs lists the references each file makes, by path
+	Refs map[string][]ThemeRef
+	// Assets maps the names of the files the build copies to assets to their src paths
+	Assets map[string]string
+	// Mentions lists, by asset-like file name, the src files that mention it anywhere
+	Mentions map[string][]string
+	// Dynamic counts render and include calls whose snippet name is a variable
+	Dynamic int
+	// Templates is whether the build directory had JSON templates to read
+	Templates bool
+	byName    map[string]int
+}
+
+// liquidTag is one {% %} tag, or one line of a {% liquid %} tag. {{ }}
+// output is listed as an echo tag, which it is equivalent to.
+type liquidTag struct {
+	Name   string
+	Markup string // everything after the name
+	Line   int
+	// Source is the tag as written between its delimiters, whose lines start at Line
+	Source string
+	// Body is the raw content up to the end tag, for tags such as schema,
+	// and BodyLine the line it starts on
+	Body     string
+	BodyLine int
+}
+
+// rawTags are block tags whose content isn't Liquid. Their content is kept
+// as the tag's Body and not parsed.
+var rawTags = map[string]bool{"comment": true, "raw": true, "schema": true, "javascript": true, "stylesheet": true}
+
+// lineAt returns the 1-based line of a byte offset
+func lineAt(content string, offset int) int {
+	return strings.Count(content[:offset], "\n") + 1
+}
+
+// liquidTags lists the tags of a Liquid file in order
+func liquidTags(content string) []liquidTag {
+	var tags []liquidTag
+	offset, line, counted := 0, 1, 0
+	nextTag, nextOutput := indexFrom(content, "{%", 0), indexFrom(content, "{{", 0)
+	lineOf := func(position int) int {
+		line += strings.Count(content[counted:position], "\n")
+		counted = position
+		return line
+	}
+	for {
+		// The next tag and the next output are searched separately, and only
+		// again once passed, so files with few of either stay linear
+		if nextTag >= 0 && nextTag < offset {
+			nextTag = indexFrom(content, "{%", offset)
+		}
+		if nextOutput >= 0 && nextOutput < offset {
+			nextOutput = indexFrom(content, "{{", offset)
+		}
+		start, closer := nextTag, "%}"
+		if nextOutput >= 0 && (start < 0 || nextOutput < start) {
+			start, closer = nextOutput, "}}"
+		}
+		if start < 0 {
+			return tags
+		}
+		end := strings.Index(content[start+2:], closer)
+		if end < 0 {
+			return tags
+		}
+		end += start + 2
+		offset = end + 2
+
+		tagLine := lineOf(start)
+		if closer == "}}" {
+			tags = append(tags, liquidTag{Name: "echo", Markup: strings.TrimSpace(strings.Trim(content[start+2:end], "-")), Line: tagLine, Source: content[start+2 : end]})
+			continue
+		}
+		name, markup := splitTag(strings.Trim(content[start+2:end], "-"))
+		switch {
+		case name == "liquid":
+			// Each line of a {% liquid %} tag is a tag of its own
+			inComment := false
+			for i, text := range strings.Split(content[start+2:end], "\n") {
+				if i == 0 {
+					_, text = splitTag(strings.TrimLeft(text, "-"))
+				}
+				lineName, lineMarkup := splitTag(strings.TrimRight(text, "-"))
+				switch {
+				case lineName == "comment":
+					inComment = true
+				case lineName == "endcomment":
+					inComment = false
+				case lineName != "" && !inComment && !strings.HasPrefix(lineName, "#"):
+					tags = append(tags, liquidTag{Name: lineName, Markup: lineMarkup, Line: tagLine + i, Source: text})
+				}
+			}
+		case rawTags[name]:
+			tag := liquidTag{Name: name, Markup: markup, Line: tagLine, Source: content[start+2 : end], BodyLine: tagLine + strings.Count(content[start:offset], "\n")}
+			if closing := strings.Index(content[offset:], "end"+name); closing >= 0 {
+				closing += offset
+				bodyEnd := strings.LastIndex(content[offset:closing], "{%")
+				if bodyEnd < 0 {
+					bodyEnd = closing - offset
+				}
+				tag.Body = content[offset : offset+bodyEnd]
+				offset = closing
+				if after := strings.Index(content[closing:], "%}"); after >= 0 {
+					offset = closing + after + 2
+				}
+			}
+			tags = append(tags, tag)
+		case name != "":
+			tags = append(tags, liquidTag{Name: name, Markup: markup, Line: tagLine, Source: content[start+2 : end]})
+		}
+	}
+}
+
+// indexFrom is strings.Index from an offset, returning an index into s
+func indexFrom(s, substr string, offset int) int {
+	if i := strings.Index(s[offset:], substr); i >= 0 {
+		return offset + i
+	}
+	return -1
+}
+
+// splitTag splits tag markup into the tag name and the rest
+func splitTag(text string) (string, string) {
+	text = strings.TrimSpace(text)
+	name, markup, _ := strings.Cut(text, " ")
+	if i := strings.IndexAny(name, "\t\n\r"); i >= 0 {
+		name, markup = name[:i], name[i+1:]+" "+markup
+	}
+	return name, strings.TrimSpace(markup)
+}
+
+// quotedName returns the leading quoted string of tag markup
+func quotedName(markup string) (string, bool) {
+	if markup == "" || (markup[0] != '\'' && markup[0] != '"') {
+		return "", false
+	}
+	end := strings.IndexByte(markup[1:], markup[0])
+	if end < 0 {
+		return "", false
+	}
+	return markup[1 : end+1], true
+}
+
+// assetURLRefs finds 'name' | asset_url (and asset_img_url) filters in the
+// source of a tag that starts on the given line
+func assetURLRefs(content string, line int) []ThemeRef {
+	var refs []ThemeRef
+	for offset := 0; ; {
+		i := strings.Index(content[offset:], "asset_")
+		if i < 0 {
+			return refs
+		}
+		i += offset
+		offset = i + len("asset_")
+		if !strings.HasPrefix(content[i:], "asset_url") && !strings.HasPrefix(content[i:], "asset_img_url") {
+			continue
+		}
+		// Walk back over "| " to the quoted name
+		before := strings.TrimRight(content[:i], " \t")
+		if !strings.HasSuffix(before, "|") {
+			continue
+		}
+		before = strings.TrimRight(before[:len(before)-1], " \t")
+		if before == "" || (before[len(before)-1] != '\'' && before[len(before)-1] != '"') {
+			continue
+		}
+		quote := before[len(before)-1]
+		open := strings.LastIndexByte(before[:len(before)-1], quote)
+		if open < 0 {
+			continue
+		}
+		name := before[open+1 : len(before)-1]
+		if name == "" || strings.ContainsAny(name, "{}|\n") {
+			continue
+		}
+		refs = append(refs, ThemeRef{Kind: "asset", Name: name, Line: line + lineAt(content, i) - 1})
+	}
+}
+
+// liquidRefs extracts the references of one Liquid file and counts the
+// render and include calls whose snippet name is a variable. Comments, inline
+// # comments and raw blocks are skipped, since they never render.
+func liquidRefs(content string) ([]ThemeRef, int) {
+	var refs []ThemeRef
+	dynamic := 0
+	for _, tag := range liquidTags(content) {
+		if rawTags[tag.Name] || strings.HasPrefix(tag.Name, "#") {
+			continue
+		}
+		refs = append(refs, assetURLRefs(tag.Source, tag.Line)...)
+		switch tag.Name {
+		case "render", "include", "section", "sections":
+			if name, ok := quotedName(tag.Markup); ok {
+				refs = append(refs, ThemeRef{Kind: tag.Name, Name: name, Line: tag.Line})
+			} else if tag.Name == "render" || tag.Name == "include" {
+				dynamic++
+			}
+		case "content_for":
+			if kind, ok := quotedName(tag.Markup); ok && kind == "block" {
+				if _, rest, ok := strings.Cut(tag.Markup, "type:"); ok {
+					if name, ok := quotedName(strings.TrimSpace(rest)); ok {
+						refs = append(refs, ThemeRef{Kind: "block", Name: name, Line: tag.Line})
+					}
+				}
+			}
+		}
+	}
+	return refs, dynamic
+}
+
+// assetExtensions are the file types a mention of a name counts for
+var assetExtensions = map[string]bool{
+	".js": true, ".mjs": true, ".css": true, ".json": true, ".svg": true, ".png": true, ".jpg": true, ".jpeg": true,
+	".gif": true, ".webp": true, ".avif": true, ".ico": true, ".woff": true, ".woff2": true, ".ttf": true,
+	".otf": true, ".eot": true, ".mp4": true, ".webm": true,
+}
+
+// mentionedFiles lists the asset-like file names that occur anywhere in a file
+func mentionedFiles(content string) []string {
+	var names []string
+	seen := make(map[string]bool)
+	for _, token := range strings.FieldsFunc(content, func(r rune) bool {
+		return !(r == '.' || r == '@' || r == '-' || r == '_' || r >= '0' && r <= '9' || r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z')
+	}) {
+		token = strings.Trim(token, ".")
+		if assetExtensions[strings.ToLower(filepath.Ext(token))] && !seen[token] {
+			seen[token] = true
+			names = append(names, token)
+		}
+	}
+	return names
+}
+
+// stripJSONComment drops the /* ... */ header Shopify writes at the top of JSON templates
+func stripJSONComment(data []byte) []byte {
+	trimmed := strings.TrimSpace(string(data))
+	if strings.HasPrefix(trimmed, "/*") {
+		if end := strings.Index(trimmed, "*/"); end >= 0 {
+			trimmed = trimmed[end+2:]
+		}
+	}
+	return []byte(trimmed)
+}
+
+// templateSection is a section, or a block within one, in a JSON template or section group
+type templateSection struct {
+	Type   string                     `json:"type"`
+	Blocks map[string]templateSection `json:"blocks"`
+}
+
+// templateRefs extracts the section and block types a JSON template or section group uses
+func templateRefs(data []byte) []ThemeRef {
+	var template struct {
+		Sections map[string]templateSection `json:"sections"`
+	}
+	if err := json.Unmarshal(stripJSONComment(data), &template); err != nil {
+		return nil
+	}
+	var refs []ThemeRef
+	var addBlocks func(blocks map[string]templateSection)
+	addBlocks = func(blocks map[string]templateSection) {
+		for _, block := range blocks {
+			if block.Type != "" && !strings.HasPrefix(block.Type, "@") {
+				refs = append(refs, ThemeRef{Kind: "block", Name: block.Type})
+			}
+			addBlocks(block.Blocks)
+		}
+	}
+	for _, section := range template.Sections {
+		if section.Type != "" {
+			refs = append(refs, ThemeRef{Kind: "section", Name: section.Type})
+		}
+		addBlocks(section.Blocks)
+	}
+	sort.Slice(refs, func(i, j int) bool {
+		if refs[i].Kind != refs[j].Kind {
+			return refs[i].Kind < refs[j].Kind
+		}
+		return refs[i].Name < refs[j].Name
+	})
+	return refs
+}
+
+// buildThemeIndex reads src and the templates and section groups of the build
+func buildThemeIndex() ThemeIndex {
+	index := ThemeIndex{
+		Refs:     make(map[string][]ThemeRef),
+		Assets:   make(map[string]string),
+		Mentions: make(map[string][]string),
+		byName:   make(map[string]int),
+	}
+	root := projectRoot()
+	srcDir := filepath.Join(root, "src")
+
+	filepath.Walk(srcDir, func(path string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() {
+			return nil
+		}
+		relative, err := filepath.Rel(srcDir, path)
+		if err != nil {
+			return nil
+		}
+		relative = filepath.ToSlash(relative)
+		project := "src/" + relative
+		destination := buildDestination(relative)
+		extension := filepath.Ext(info.Name())
+
+		// src/styles holds build inputs, compiled into tailwind.css rather than copied
+		if destination == "assets" && !strings.HasPrefix(relative, "styles/") {
+			if _, ok := index.Assets[info.Name()]; !ok {
+				index.Assets[info.Name()] = project
+			}
+		}
+		switch extension {
+		case ".liquid", ".js", ".mjs", ".css", ".json":
+		default:
+			return nil
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil
+		}
+		content := string(data)
+		for _, name := range mentionedFiles(content) {
+			index.Mentions[name] = append(index.Mentions[name], project)
+		}
+		if extension == ".liquid" && strings.HasPrefix(relative, "liquid/") {
+			index.addFile(ThemeFile{
+				Kind: strings.TrimSuffix(destination, "s"),
+				Name: strings.TrimSuffix(info.Name(), extension),
+				Path: project,
+			})
+			refs, dynamic := liquidRefs(content)
+			index.Refs[project] = refs
+			index.Dynamic += dynamic
+		}
+		return nil
+	})
+
+	buildDir := filepath.Join(root, buildDirName)
+	filepath.Walk(filepath.Join(buildDir, "templates"), func(path string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() {
+			return nil
+		}
+		relative, _ := filepath.Rel(root, path)
+		relative = filepath.ToSlash(relative)
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil
+		}
+		extension := filepath.Ext(path)
+		file := ThemeFile{Kind: "template", Name: strings.TrimSuffix(info.Name(), extension), Path: relative}
+		switch extension {
+		case ".json":
+			index.Templates = true
+			index.addFile(file)
+			index.Refs[relative] = templateRefs(data)
+		case ".liquid":
+			index.addFile(file)
+			refs, dynamic := liquidRefs(string(data))
+			index.Refs[relative] = refs
+			index.Dynamic += dynamic
+		}
+		return nil
+	})
+	groups, _ := filepath.Glob(filepath.Join(buildDir, "sections", "*.json"))
+	for _, path := range groups {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			continue
+		}
+		relative, _ := filepath.Rel(root, path)
+		relative = filepath.ToSlash(relative)
+		index.addFile(ThemeFile{Kind: "group", Name: strings.TrimSuffix(filepath.Base(path), ".json"), Path: relative})
+		index.Refs[relative] = templateRefs(data)
+	}
+
+	sort.Slice(index.Files, func(i, j int) bool { return index.Files[i].Path < index.Files[j].Path })
+	for i, file := range index.Files {
+		index.byName[file.Kind+"/"+file.Name] = i
+	}
+	return index
+}
+
+func (index *ThemeIndex) addFile(file ThemeFile) {
+	// The build flattens src, so the first file with a name wins, as it does there
+	if _, ok := index.byName[file.Kind+"/"+file.Name]; ok {
+		return
+	}
+	index.byName[file.Kind+"/"+file.Name] = len(index.Files)
+	index.Files = append(index.Files, file)
+}
+
+// Lookup finds a file by kind and name
+func (index ThemeIndex) Lookup(kind, name string) (ThemeFile, bool) {
+	i, ok := index.byName[kind+"/"+name]
+	if !ok {
+		return ThemeFile{}, false
+	}
+	return index.Files[i], true
+}
+
+// Resolve returns the file a reference points at, if the theme has it
+func (index ThemeIndex) Resolve(ref ThemeRef) (ThemeFile, bool) {
+	if ref.Kind == "asset" {
+		return ThemeFile{}, false
+	}
+	return index.Lookup(ref.Target(), ref.Name)
+}
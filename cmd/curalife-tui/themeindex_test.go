@@ -0,0 +1,162 @@
+package main
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestLiquidRefs(t *testing.T) {
+	tests := []struct {
+		name        string
+		content     string
+		want        []ThemeRef
+		wantDynamic int
+	}{
+		{
+			name: "render, include, sections and blocks",
+			content: `{% render 'price', product: product %}
+{%- include "icon-cart" -%}
+{% section 'header' %}
+{% sections 'footer-group' %}
+{% content_for 'block', type: 'slide', id: 'slide-1' %}`,
+			want: []ThemeRef{
+				{Kind: "render", Name: "price", Line: 1},
+				{Kind: "include", Name: "icon-cart", Line: 2},
+				{Kind: "section", Name: "header", Line: 3},
+				{Kind: "sections", Name: "footer-group", Line: 4},
+				{Kind: "block", Name: "slide", Line: 5},
+			},
+		},
+		{
+			name: "variable snippet names",
+			content: `{% render block.type, block: block %}
+{% include snippet_name %}
+{% render 'product-card' for collection.products as product %}`,
+			want:        []ThemeRef{{Kind: "render", Name: "product-card", Line: 3}},
+			wantDynamic: 2,
+		},
+		{
+			name: "comment blocks",
+			content: `{% comment %}
+  {% render 'old-banner' %}
+  <script src="{{ 'old.js' | asset_url }}"></script>
+{% endcomment %}
+{% comment %} <img src="{{ 'raf-icon-1.png' | asset_url }}"> {% endcomment %}
+{% render 'banner' %}`,
+			want: []ThemeRef{{Kind: "render", Name: "banner", Line: 6}},
+		},
+		{
+			name: "raw blocks",
+			content: `{% raw %}{% render 'example' %} {{ 'example.js' | asset_url }}{% endraw %}
+{{ 'theme.css' | asset_url | stylesheet_tag }}`,
+			want: []ThemeRef{{Kind: "asset", Name: "theme.css", Line: 2}},
+		},
+		{
+			name: "inline comments",
+			content: `{% # render 'old-banner' %}
+{%- # 'old.js' | asset_url -%}
+{% render 'banner' %}`,
+			want: []ThemeRef{{Kind: "render", Name: "banner", Line: 3}},
+		},
+		{
+			name: "liquid tag",
+			content: `{% liquid
+  render 'price'
+  # render 'old-price'
+  comment
+    render 'compare-price'
+  endcomment
+  echo 'price.css' | asset_url | stylesheet_tag
+%}`,
+			want: []ThemeRef{
+				{Kind: "render", Name: "price", Line: 2},
+				{Kind: "asset", Name: "price.css", Line: 7},
+			},
+		},
+		{
+			name: "multi-line render",
+			content: `<div class="trust-badges-icons">
+	{%-
+		render 'svg-fill',
+		svg_url: 'trust-badges-icons.svg' | asset_url,
+		fill_color: 'var(--primary)'
+	-%}
+</div>`,
+			want: []ThemeRef{
+				{Kind: "asset", Name: "trust-badges-icons.svg", Line: 4},
+				{Kind: "render", Name: "svg-fill", Line: 2},
+			},
+		},
+		{
+			name: "asset_url in outputs, filters and HTML comments",
+			content: `<link rel="icon" href="{{ "favicon.png" | asset_img_url: '32x32' }}">
+{% assign hero = 'hero.webp' | asset_url %}
+<!-- HTML comments still render: {{ 'legacy.js' | asset_url }} -->
+{{ product.featured_image | image_url }}`,
+			want: []ThemeRef{
+				{Kind: "asset", Name: "favicon.png", Line: 1},
+				{Kind: "asset", Name: "hero.webp", Line: 2},
+				{Kind: "asset", Name: "legacy.js", Line: 3},
+			},
+		},
+		{
+			name: "schema and stylesheet bodies are not Liquid",
+			content: `{% stylesheet %}
+  .hero { background: url("{{ 'hero.webp' | asset_url }}"); }
+{% endstylesheet %}
+{% schema %}
+  {"name": "Hero", "settings": [{"type": "text", "id": "note", "default": "{% render 'x' %}"}]}
+{% endschema %}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			refs, dynamic := liquidRefs(tt.content)
+			if len(refs)+len(tt.want) > 0 && !reflect.DeepEqual(refs, tt.want) {
+				t.Errorf("refs = %+v, want %+v", refs, tt.want)
+			}
+			if dynamic != tt.wantDynamic {
+				t.Errorf("dynamic = %d, want %d", dynamic, tt.wantDynamic)
+			}
+		})
+	}
+}
+
+func TestLiquidTags(t *testing.T) {
+	content := `{% if product %}
+  {{ product.title | escape }}
+{% endif %}
+{% schema %}
+{"name": "Product"}
+{% endschema %}
+{% render 'after' %}`
+
+	var got []string
+	for _, tag := range liquidTags(content) {
+		got = append(got, tag.Name+" "+tag.Markup)
+	}
+	want := []string{"if product", "echo product.title | escape", "endif ", "schema ", "render 'after'"}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("tags = %q, want %q", got, want)
+	}
+
+	schema := liquidTags(content)[3]
+	if strings.TrimSpace(schema.Body) != `{"name": "Product"}` || schema.Line != 4 || schema.BodyLine != 4 {
+		t.Errorf("schema = body %q line %d body line %d, want the JSON on line 4", schema.Body, schema.Line, schema.BodyLine)
+	}
+	if last := liquidTags(content)[4]; last.Line != 7 {
+		t.Errorf("tag after the schema on line %d, want 7", last.Line)
+	}
+}
+
+func TestFindUnusedJSONListsNoFilesAsEmpty(t *testing.T) {
+	data, err := json.Marshal(findUnused(ThemeIndex{}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), `"files":[]`) {
+		t.Errorf("report = %s, want an empty files list", data)
+	}
+}
@@ -0,0 +1,179 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+)
+
+// Step states, matching the status field of the adapter's progress events
+const (
+	StepRunning   = "running"
+	StepCompleted = "completed"
+	StepFailed    = "failed"
+	StepStopped   = "stopped"
+)
+
+// StepSpan is one build step on the timeline. End is zero while the step runs.
+type StepSpan struct {
+	Name   string    `json:"name"`
+	Start  time.Time `json:"start"`
+	End    time.Time `json:"end"`
+	Status string    `json:"status"`
+}
+
+// Running reports whether the step has not finished yet
+func (s StepSpan) Running() bool {
+	return s.End.IsZero()
+}
+
+// Duration returns how long the step took, or has taken so far
+func (s StepSpan) Duration(now time.Time) time.Duration {
+	if s.Running() {
+		return now.Sub(s.Start)
+	}
+	return s.End.Sub(s.Start)
+}
+
+// Overlaps reports whether two steps ran at the same time
+func (s StepSpan) Overlaps(other StepSpan, now time.Time) bool {
+	return s.Start.Before(other.Start.Add(other.Duration(now))) && other.Start.Before(s.Start.Add(s.Duration(now)))
+}
+
+// recordStep updates the timeline from a progress event. Steps stay open until
+// the adapter reports them finished, so steps running in parallel overlap.
+// Caller must hold the mutex.
+func (b *Backend) recordStep(name, status string, at time.Time) {
+	steps := b.buildStatus.Steps
+	index := -1
+	for i := range steps {
+		if steps[i].Name == name && steps[i].Running() {
+			index = i
+		}
+	}
+
+	if index < 0 {
+		start := at
+		if status != StepRunning {
+			// The step finished before it was seen to start, so it began when the
+			// previous one ended, or when initialization started if none has
+			start = b.lastStepEnd()
+			for _, step := range steps {
+				if step.Name == "init" && step.Running() && step.Start.After(start) {
+					start = step.Start
+				}
+			}
+		}
+		// Initialization ends when the first real step starts
+		if name != "init" {
+			b.closeSteps("init", StepCompleted, start)
+		}
+		b.buildStatus.Steps = append(b.buildStatus.Steps, StepSpan{Name: name, Start: start, Status: StepRunning})
+		index = len(b.buildStatus.Steps) - 1
+	}
+
+	if status == StepCompleted || status == StepFailed {
+		b.buildStatus.Steps[index].End = at
+		b.buildStatus.Steps[index].Status = status
+	}
+}
+
+// closeSteps finishes running steps with the given name, or every running step
+// when name is empty. Caller must hold the mutex.
+func (b *Backend) closeSteps(name, status string, at time.Time) {
+	for i, step := range b.buildStatus.Steps {
+		if step.Running() && (name == "" || step.Name == name) {
+			b.buildStatus.Steps[i].End = at
+			b.buildStatus.Steps[i].Status = status
+		}
+	}
+}
+
+// lastStepEnd returns when the latest finished step ended. Caller must hold the mutex.
+func (b *Backend) lastStepEnd() time.Time {
+	last := b.buildStatus.StartedAt
+	for _, step := range b.buildStatus.Steps {
+		if step.End.After(last) {
+			last = step.End
+		}
+	}
+	return last
+}
+
+// renderTimeline draws the steps as a Gantt chart, one row per step on a shared time axis
+func renderTimeline(steps []StepSpan, now time.Time, width int) string {
+	if len(steps) == 0 {
+		return ""
+	}
+
+	origin, end := steps[0].Start, steps[0].Start
+	labelWidth := 0
+	for _, step := range steps {
+		if step.Start.Before(origin) {
+			origin = step.Start
+		}
+		if stepEnd := step.Start.Add(step.Duration(now)); stepEnd.After(end) {
+			end = stepEnd
+		}
+		labelWidth = max(labelWidth, len(step.Name))
+	}
+	total := end.Sub(origin)
+	if total <= 0 {
+		total = time.Millisecond
+	}
+
+	if width <= 0 {
+		width = 80
+	}
+	// Label, duration and status columns take the rest of the line
+	barWidth := min(max(width-labelWidth-22, 20), 60)
+	column := func(t time.Time) float64 {
+		return float64(t.Sub(origin)) / float64(total) * float64(barWidth)
+	}
+
+	s := ""
+	for i, step := range steps {
+		from := int(column(step.Start))
+		to := int(math.Ceil(column(step.Start.Add(step.Duration(now)))))
+		from = min(from, barWidth-1)
+		to = min(max(to, from+1), barWidth)
+
+		style, fill := progressStyle, "█"
+		switch step.Status {
+		case StepRunning:
+			style, fill = detailStyle, "▓"
+		case StepFailed:
+			style = errorStyle
+		case StepStopped:
+			style, fill = detailStyle, "▒"
+		}
+		bar := strings.Repeat(" ", from) + style.Render(strings.Repeat(fill, to-from)) + strings.Repeat(" ", barWidth-to)
+
+		parallel := ""
+		for j, other := range steps {
+			if j != i && step.Overlaps(other, now) {
+				parallel = " ∥"
+				break
+			}
+		}
+		s += fmt.Sprintf("  %-*s │%s│ %8s %s%s\n", labelWidth, step.Name, bar,
+			formatMillis(step.Duration(now).Milliseconds()), stepStatusIcon(step.Status), parallel)
+	}
+
+	axis := fmt.Sprintf("%*s", barWidth, formatMillis(total.Milliseconds()))
+	s += detailStyle.Render(fmt.Sprintf("  %-*s  0%s", labelWidth, "", axis[1:])) + "\n"
+	return s
+}
+
+func stepStatusIcon(status string) string {
+	switch status {
+	case StepRunning:
+		return "🔄"
+	case StepCompleted:
+		return "✅"
+	case StepFailed:
+		return "❌"
+	}
+	return "⏹️"
+}
@@ -0,0 +1,118 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRecordStep(t *testing.T) {
+	type event struct {
+		phase  string // adapter phase, or "complete" to close every step
+		status string
+		ms     int
+	}
+	type span struct {
+		name       string
+		start, end int // ms after the build started; -1 while running
+		status     string
+	}
+	tests := []struct {
+		name   string
+		events []event
+		want   []span
+	}{
+		{
+			// build-adapter.js starts on the copy step without announcing it,
+			// so the first copy event is the one that finishes it
+			name: "first step seen completed",
+			events: []event{
+				{"init", StepRunning, 2},
+				{"copy", StepCompleted, 30},
+				{"styles", StepRunning, 30},
+				{"styles", StepCompleted, 80},
+				{"scripts", StepRunning, 80},
+				{"complete", StepCompleted, 120},
+			},
+			want: []span{
+				{"init", 2, 2, StepCompleted},
+				{"File Copy", 2, 30, StepCompleted},
+				{"Tailwind CSS", 30, 80, StepCompleted},
+				{"Vite Build", 80, 120, StepCompleted},
+			},
+		},
+		{
+			name: "every step announced",
+			events: []event{
+				{"init", StepRunning, 0},
+				{"copy", StepRunning, 10},
+				{"copy", StepCompleted, 40},
+				{"styles", StepRunning, 40},
+			},
+			want: []span{
+				{"init", 0, 10, StepCompleted},
+				{"File Copy", 10, 40, StepCompleted},
+				{"Tailwind CSS", 40, -1, StepRunning},
+			},
+		},
+		{
+			name: "later step seen completed starts when the previous one ended",
+			events: []event{
+				{"init", StepRunning, 0},
+				{"copy", StepRunning, 5},
+				{"copy", StepCompleted, 25},
+				{"styles", StepCompleted, 60},
+			},
+			want: []span{
+				{"init", 0, 5, StepCompleted},
+				{"File Copy", 5, 25, StepCompleted},
+				{"Tailwind CSS", 25, 60, StepCompleted},
+			},
+		},
+		{
+			name: "parallel steps overlap",
+			events: []event{
+				{"styles", StepRunning, 0},
+				{"scripts", StepRunning, 5},
+				{"styles", StepCompleted, 50},
+				{"scripts", StepFailed, 70},
+			},
+			want: []span{
+				{"Tailwind CSS", 0, 50, StepCompleted},
+				{"Vite Build", 5, 70, StepFailed},
+			},
+		},
+	}
+
+	started := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	at := func(ms int) time.Time {
+		return started.Add(time.Duration(ms) * time.Millisecond)
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := &Backend{buildStatus: BuildStatus{StartedAt: started}}
+			for _, e := range tt.events {
+				if e.phase == "complete" {
+					b.closeSteps("", e.status, at(e.ms))
+					continue
+				}
+				b.recordStep(adapterSteps[e.phase], e.status, at(e.ms))
+			}
+
+			steps := b.buildStatus.Steps
+			if len(steps) != len(tt.want) {
+				t.Fatalf("got %d steps %+v, want %d", len(steps), steps, len(tt.want))
+			}
+			for i, want := range tt.want {
+				got := steps[i]
+				wantEnd := time.Time{}
+				if want.end >= 0 {
+					wantEnd = at(want.end)
+				}
+				if got.Name != want.name || !got.Start.Equal(at(want.start)) || !got.End.Equal(wantEnd) || got.Status != want.status {
+					t.Errorf("step %d = %s %v–%v %s, want %s %dms–%dms %s", i, got.Name,
+						got.Start.Sub(started), got.End.Sub(started), got.Status, want.name, want.start, want.end, want.status)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,371 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// unusedKinds are the tabs of the unused files screen
+var unusedKinds = []string{"snippet", "section", "asset"}
+
+// UnusedFile is a src file nothing in the theme uses
+type UnusedFile struct {
+	Kind   string `json:"kind"`
+	Name   string `json:"name"`
+	Path   string `json:"path"`
+	Size   int64  `json:"size"`
+	Reason string `json:"reason"`
+}
+
+// UnusedReport lists the unused snippets, sections and assets
+type UnusedReport struct {
+	Files []UnusedFile `json:"files"`
+	// SectionsChecked is false when the build had no JSON templates, so
+	// every section counts as used
+	SectionsChecked bool      `json:"sectionsChecked"`
+	DynamicRenders  int       `json:"dynamicRenders"`
+	ScannedAt       time.Time `json:"scannedAt"`
+}
+
+// ByKind returns the unused files of one kind
+func (r UnusedReport) ByKind(kind string) []UnusedFile {
+	var files []UnusedFile
+	for _, file := range r.Files {
+		if file.Kind == kind {
+			files = append(files, file)
+		}
+	}
+	return files
+}
+
+// usedBy explains why a file nobody reaches is unused
+func usedBy(sources []string) string {
+	if len(sources) == 0 {
+		return "never referenced"
+	}
+	names := make([]string, len(sources))
+	for i, source := range sources {
+		names[i] = filepath.Base(source)
+	}
+	sort.Strings(names)
+	return "only used by unused " + strings.Join(names, ", ")
+}
+
+// findUnused walks the theme from its roots — layouts, templates, section
+// groups and theme blocks — and reports the snippets, sections and assets it
+// never reaches. Without JSON templates sections can't be traced, so they
+// are all treated as roots.
+func findUnused(index ThemeIndex) UnusedReport {
+	report := UnusedReport{Files: []UnusedFile{}, SectionsChecked: index.Templates, DynamicRenders: index.Dynamic, ScannedAt: time.Now()}
+
+	reachable := make(map[string]bool)
+	var queue []string
+	for _, file := range index.Files {
+		switch file.Kind {
+		case "layout", "template", "group", "block":
+		case "section":
+			if index.Templates {
+				continue
+			}
+		default:
+			continue
+		}
+		reachable[file.Path] = true
+		queue = append(queue, file.Path)
+	}
+	for len(queue) > 0 {
+		path := queue[0]
+		queue = queue[1:]
+		for _, ref := range index.Refs[path] {
+			if target, ok := index.Resolve(ref); ok && !reachable[target.Path] {
+				reachable[target.Path] = true
+				queue = append(queue, target.Path)
+			}
+		}
+	}
+
+	// Who refers to each file and asset, to explain unused ones
+	referrers := make(map[string][]string)
+	usedAssets := make(map[string]bool)
+	for path, refs := range index.Refs {
+		for _, ref := range refs {
+			if ref.Kind == "asset" {
+				usedAssets[ref.Name] = usedAssets[ref.Name] || reachable[path]
+				continue
+			}
+			if target, ok := index.Resolve(ref); ok && target.Path != path {
+				referrers[target.Path] = append(referrers[target.Path], path)
+			}
+		}
+	}
+
+	size := func(path string) int64 {
+		if info, err := os.Stat(filepath.Join(projectRoot(), path)); err == nil {
+			return info.Size()
+		}
+		return 0
+	}
+	for _, file := range index.Files {
+		if (file.Kind != "snippet" && file.Kind != "section") || reachable[file.Path] {
+			continue
+		}
+		report.Files = append(report.Files, UnusedFile{
+			Kind:   file.Kind,
+			Name:   file.Name,
+			Path:   file.Path,
+			Size:   size(file.Path),
+			Reason: usedBy(dedupe(referrers[file.Path])),
+		})
+	}
+
+	indexed := make(map[string]bool, len(index.Files))
+	for _, file := range index.Files {
+		indexed[file.Path] = true
+	}
+	var assets []UnusedFile
+	for name, path := range index.Assets {
+		if usedAssets[name] {
+			continue
+		}
+		used := false
+		var unusedReferrers []string
+		for _, mention := range index.Mentions[name] {
+			switch {
+			case mention == path:
+			case !indexed[mention] || reachable[mention]:
+				used = true
+			default:
+				unusedReferrers = append(unusedReferrers, mention)
+			}
+		}
+		if !used {
+			assets = append(assets, UnusedFile{Kind: "asset", Name: name, Path: path, Size: size(path), Reason: usedBy(dedupe(unusedReferrers))})
+		}
+	}
+	sort.Slice(assets, func(i, j int) bool { return assets[i].Path < assets[j].Path })
+	report.Files = append(report.Files, assets...)
+	return report
+}
+
+// dedupe drops repeated strings, keeping the first of each
+func dedupe(values []string) []string {
+	seen := make(map[string]bool, len(values))
+	var unique []string
+	for _, value := range values {
+		if !seen[value] {
+			seen[value] = true
+			unique = append(unique, value)
+		}
+	}
+	return unique
+}
+
+// backupUnused moves files into backups/unused-<time>, keeping their paths
+// under it so they can be restored by moving them back
+func backupUnused(files []UnusedFile) (string, int, error) {
+	dir := filepath.Join("backups", "unused-"+time.Now().Format("20060102-150405"))
+	moved := 0
+	for _, file := range files {
+		destination := filepath.Join(projectRoot(), dir, file.Path)
+		if err := os.MkdirAll(filepath.Dir(destination), 0o755); err != nil {
+			return dir, moved, fmt.Errorf("failed to create %s: %v", filepath.Dir(destination), err)
+		}
+		if err := os.Rename(filepath.Join(projectRoot(), file.Path), destination); err != nil {
+			return dir, moved, fmt.Errorf("failed to move %s: %v", file.Path, err)
+		}
+		moved++
+	}
+	return dir, moved, nil
+}
+
+func runUnusedCommand(args []string) int {
+	flags := flag.NewFlagSet("unused", flag.ContinueOnError)
+	jsonOutput := flags.Bool("json", false, "print the report as JSON")
+	move := flags.Bool("move", false, "move the unused files into backups/")
+	force := flags.Bool("force", false, "move even when render calls use a variable snippet name")
+	if err := flags.Parse(args); err == flag.ErrHelp {
+		return exitOK
+	} else if err != nil {
+		return exitUsage
+	}
+
+	report := findUnused(buildThemeIndex())
+	if *jsonOutput {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		encoder.Encode(report)
+	} else {
+		for _, kind := range unusedKinds {
+			files := report.ByKind(kind)
+			fmt.Printf("%s (%d unused)\n", strings.ToUpper(kind[:1])+kind[1:]+"s", len(files))
+			for _, file := range files {
+				fmt.Printf("  %-60s %10s  %s\n", file.Path, formatBytes(file.Size), file.Reason)
+			}
+		}
+		if !report.SectionsChecked {
+			fmt.Printf("⚠️  No JSON templates in %s/templates — sections were not checked\n", buildDirName)
+		}
+		if report.DynamicRenders > 0 {
+			fmt.Printf("⚠️  %d render calls use a variable snippet name; snippets they load may be listed\n", report.DynamicRenders)
+		}
+	}
+
+	if *move && report.DynamicRenders > 0 && !*force {
+		fmt.Fprintf(os.Stderr, "❌ Not moving: %d render calls use a variable snippet name and may load listed snippets; check them and pass --force\n", report.DynamicRenders)
+		return exitFailed
+	}
+	if *move && len(report.Files) > 0 {
+		dir, moved, err := backupUnused(report.Files)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+			return exitFailed
+		}
+		fmt.Fprintf(os.Stderr, "📦 Moved %d files to %s\n", moved, dir)
+	}
+	return exitOK
+}
+
+func (m Model) openUnused() Model {
+	m.state = StateUnused
+	m.unused = findUnused(buildThemeIndex())
+	m.unusedCursor = 0
+	m.unusedMarked = make(map[string]bool)
+	m.unusedMoveArmed = false
+	m.statusMessage = ""
+	return m
+}
+
+func (m Model) handleUnusedKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	// Moving needs a second M; any other key cancels it
+	armed := m.unusedMoveArmed
+	m.unusedMoveArmed = false
+	files := m.unused.ByKind(unusedKinds[m.unusedTab])
+
+	switch msg.String() {
+	case "ctrl+c", "q":
+		m.backend.Cleanup()
+		return m, tea.Quit
+	case "esc":
+		m.state = StateMenu
+	case "right", "l", "tab":
+		m.unusedTab = (m.unusedTab + 1) % len(unusedKinds)
+		m.unusedCursor = 0
+	case "left", "h":
+		m.unusedTab = (m.unusedTab + len(unusedKinds) - 1) % len(unusedKinds)
+		m.unusedCursor = 0
+	case "up", "k":
+		if m.unusedCursor > 0 {
+			m.unusedCursor--
+		}
+	case "down", "j":
+		if m.unusedCursor < len(files)-1 {
+			m.unusedCursor++
+		}
+	case " ":
+		if m.unusedCursor < len(files) {
+			path := files[m.unusedCursor].Path
+			m.unusedMarked[path] = !m.unusedMarked[path]
+		}
+	case "a":
+		all := true
+		for _, file := range files {
+			all = all && m.unusedMarked[file.Path]
+		}
+		for _, file := range files {
+			m.unusedMarked[file.Path] = !all
+		}
+	case "e", "enter":
+		if m.unusedCursor < len(files) {
+			return m, openInEditor(FileRef{Path: filepath.Join(projectRoot(), files[m.unusedCursor].Path)})
+		}
+	case "r":
+		return m.openUnused(), nil
+	case "m":
+		var marked []UnusedFile
+		for _, file := range m.unused.Files {
+			if m.unusedMarked[file.Path] {
+				marked = append(marked, file)
+			}
+		}
+		if len(marked) == 0 {
+			m.statusMessage = "Mark files with space first"
+			return m, nil
+		}
+		if !armed {
+			m.unusedMoveArmed = true
+			m.statusMessage = fmt.Sprintf("⚠️  Press M again to move %d marked files to backups/", len(marked))
+			return m, nil
+		}
+		dir, moved, err := backupUnused(marked)
+		m = m.openUnused()
+		if err != nil {
+			m.statusMessage = fmt.Sprintf("❌ %v (%d moved to %s)", err, moved, dir)
+		} else {
+			m.statusMessage = fmt.Sprintf("📦 Moved %d files to %s", moved, dir)
+		}
+	}
+	return m, nil
+}
+
+func (m Model) renderUnused() string {
+	s := "\n"
+	s += titleStyle.Render("🗑️  UNUSED FILES") + "\n\n"
+
+	for i, kind := range unusedKinds {
+		label := fmt.Sprintf(" %ss (%d) ", kind, len(m.unused.ByKind(kind)))
+		if i == m.unusedTab {
+			s += selectedStyle.Render(label)
+		} else {
+			s += normalStyle.Render(label)
+		}
+	}
+	s += "\n\n"
+	if !m.unused.SectionsChecked {
+		s += errorStyle.Render(fmt.Sprintf("⚠️  No JSON templates in %s/templates — sections count as used; run a Shopify pull to check them", buildDirName)) + "\n"
+	}
+	if m.unused.DynamicRenders > 0 {
+		s += detailStyle.Render(fmt.Sprintf("%d render calls use a variable snippet name — check before moving snippets", m.unused.DynamicRenders)) + "\n"
+	}
+
+	files := m.unused.ByKind(unusedKinds[m.unusedTab])
+	if len(files) == 0 {
+		s += statusStyle.Render(fmt.Sprintf("✅ No unused %ss", unusedKinds[m.unusedTab])) + "\n"
+	}
+
+	var lines []string
+	var total int64
+	for i, file := range files {
+		total += file.Size
+		mark := "[ ]"
+		if m.unusedMarked[file.Path] {
+			mark = "[x]"
+		}
+		line := truncate(fmt.Sprintf("%s %-50s %9s  %s", mark, file.Path, formatBytes(file.Size), file.Reason), max(m.width-4, 60))
+		if i == m.unusedCursor {
+			lines = append(lines, selectedStyle.Render("> "+line))
+		} else {
+			lines = append(lines, normalStyle.Render("  "+line))
+		}
+	}
+	if len(lines) > 0 {
+		visible := max(m.visibleLines()-6, 5)
+		scroll := min(max(m.unusedCursor-visible/2, 0), max(len(lines)-visible, 0))
+		s += m.renderScrolled(lines, scroll)
+		s += "\n" + detailStyle.Render(fmt.Sprintf("%d files, %s", len(files), formatBytes(total))) + "\n"
+	}
+
+	if m.statusMessage != "" {
+		s += "\n" + infoStyle.Render(m.statusMessage) + "\n"
+	}
+
+	s += "\n" + helpStyle.Render("←/→: snippets/sections/assets • ↑/↓: select • space: mark • a: mark all • m: move marked to backups/ • e: open • r: rescan • esc: return to menu") + "\n"
+	return s
+}
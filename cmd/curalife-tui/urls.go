@@ -0,0 +1,101 @@
+package main
+
+import (
+	"encoding/base64"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// URLActionMsg reports the outcome of opening or copying a URL
+type URLActionMsg struct {
+	Status string
+	Err    error
+}
+
+// openURL opens the URL in the default browser
+func openURL(url string) tea.Cmd {
+	return func() tea.Msg {
+		if url == "" {
+			return URLActionMsg{Err: fmt.Errorf("no URL available yet")}
+		}
+
+		var cmd *exec.Cmd
+		switch runtime.GOOS {
+		case "windows":
+			cmd = exec.Command("cmd", "/c", "start", "", url)
+		case "darwin":
+			cmd = exec.Command("open", url)
+		default:
+			cmd = exec.Command("xdg-open", url)
+		}
+
+		if err := cmd.Start(); err != nil {
+			return URLActionMsg{Err: fmt.Errorf("failed to open %s: %v", url, err)}
+		}
+		go cmd.Wait()
+		return URLActionMsg{Status: fmt.Sprintf("🌐 Opened %s", url)}
+	}
+}
+
+// copyURL copies the URL to the system clipboard with the OSC 52 escape
+// sequence, which also works over SSH in terminals that support it
+func copyURL(url string) tea.Cmd {
+	if url == "" {
+		return func() tea.Msg {
+			return URLActionMsg{Err: fmt.Errorf("no URL available yet")}
+		}
+	}
+	return copyToClipboard(url, fmt.Sprintf("📋 Copied %s", url))
+}
+
+// clipboardMsg asks Update to copy text to the clipboard, for commands that
+// produce the text off the UI goroutine
+type clipboardMsg struct {
+	Text   string
+	Status string
+}
+
+// copyToClipboard writes the OSC 52 sequence through the program's output
+// while bubbletea holds rendering, so it can't interleave with a frame
+func copyToClipboard(text, status string) tea.Cmd {
+	return tea.Exec(&clipboardWrite{text: text}, func(err error) tea.Msg {
+		if err != nil {
+			return URLActionMsg{Err: fmt.Errorf("failed to copy to the clipboard: %v", err)}
+		}
+		return URLActionMsg{Status: status}
+	})
+}
+
+// clipboardWrite is a tea.ExecCommand that writes the clipboard sequence to the program's output
+type clipboardWrite struct {
+	text   string
+	output io.Writer
+}
+
+func (c *clipboardWrite) Run() error {
+	_, err := fmt.Fprint(c.output, osc52(c.text))
+	return err
+}
+
+func (c *clipboardWrite) SetStdin(io.Reader)    {}
+func (c *clipboardWrite) SetStdout(w io.Writer) { c.output = w }
+func (c *clipboardWrite) SetStderr(io.Writer)   {}
+
+// osc52 builds the clipboard escape sequence, wrapped for tmux and screen when needed
+func osc52(text string) string {
+	seq := "\x1b]52;c;" + base64.StdEncoding.EncodeToString([]byte(text)) + "\a"
+
+	switch {
+	case os.Getenv("TMUX") != "":
+		return "\x1bPtmux;" + strings.ReplaceAll(seq, "\x1b", "\x1b\x1b") + "\x1b\\"
+	case strings.HasPrefix(os.Getenv("TERM"), "screen"):
+		return "\x1bP" + seq + "\x1b\\"
+	}
+	return seq
+}